@@ -0,0 +1,49 @@
+package calculator
+
+import "math"
+
+// builtin is a function available to every expression
+// maxArgs is -1 for variadic functions; angular functions take angles in the Env's AngleUnit
+type builtin struct {
+	minArgs, maxArgs int
+	fn               func(args []float64) float64
+	angular          bool
+}
+
+// builtins are the predefined functions, keyed by name
+var builtins = map[string]builtin{
+	"sqrt": unaryBuiltin(math.Sqrt),
+	"sin":  angularBuiltin(math.Sin),
+	"cos":  angularBuiltin(math.Cos),
+	"log":  unaryBuiltin(math.Log),
+	"exp":  unaryBuiltin(math.Exp),
+	"abs":  unaryBuiltin(math.Abs),
+	"pow":  {minArgs: 2, maxArgs: 2, fn: func(args []float64) float64 { return Power(args[0], args[1]) }},
+	"min":  {minArgs: 1, maxArgs: -1, fn: func(args []float64) float64 { return fold(args, math.Min) }},
+	"max":  {minArgs: 1, maxArgs: -1, fn: func(args []float64) float64 { return fold(args, math.Max) }},
+}
+
+// constants are the predefined read-only names
+var constants = map[string]float64{
+	"pi": math.Pi,
+	"e":  math.E,
+}
+
+func unaryBuiltin(f func(float64) float64) builtin {
+	return builtin{minArgs: 1, maxArgs: 1, fn: func(args []float64) float64 { return f(args[0]) }}
+}
+
+func angularBuiltin(f func(float64) float64) builtin {
+	b := unaryBuiltin(f)
+	b.angular = true
+	return b
+}
+
+// fold reduces args from left to right with f
+func fold(args []float64, f func(a, b float64) float64) float64 {
+	acc := args[0]
+	for _, v := range args[1:] {
+		acc = f(acc, v)
+	}
+	return acc
+}
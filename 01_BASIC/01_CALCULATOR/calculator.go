@@ -5,33 +5,34 @@ import (
 	"math"
 )
 
+// ErrDivisionByZero is returned by Divide when the divisor is 0
+var ErrDivisionByZero = errors.New("division by zero")
+
 // Adds two numbers and returns the result
 func Add(a, b float64) float64 {
-	// TODO: Implement this function
-	return 0
+	return a + b
 }
 
 // Subtracts b from a and returns the result
 func Subtract(a, b float64) float64 {
-	// TODO: Implement this function
-	return 0
+	return a - b
 }
 
 // Multiply two numbers and returns the result
 func Multiply(a, b float64) float64 {
-	// TODO: Implement this function
-	return 0
+	return a * b
 }
 
 // Divide a between b y returns the result
 // If b is 0, returns an error
 func Divide(a, b float64) (float64, error) {
-	// TODO: Implement this function
-	return 0, nil
+	if b == 0 {
+		return 0, ErrDivisionByZero
+	}
+	return a / b, nil
 }
 
 // Calculates a raised to the power b
 func Power(base, exponent float64) float64 {
-	// TODO: Implement this function
-	return 0
-}
\ No newline at end of file
+	return math.Pow(base, exponent)
+}
@@ -0,0 +1,273 @@
+// Command calc evaluates calculator expressions
+//
+// Without arguments it starts an interactive session when stdin is a terminal,
+// and otherwise evaluates every line read from stdin
+// Expressions can also be passed with one or more -e flags
+//
+//	calc -e "x = 3" -e "x^2"
+//	echo "1 / 0" | calc   # exits with status 1
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	calculator "github.com/bssanchez/golang-practice/01_BASIC/01_CALCULATOR"
+)
+
+const help = `Enter an expression, "x = expr" or "f(a, b) = expr"
+End a line with \ or leave a parenthesis open to continue on the next line
+Commands:
+  :vars                list variables and functions
+  :precision [n|auto]  show or set the significant digits printed
+  :mode [rad|deg]      show or set the angle unit of sin and cos
+  :history             list previous inputs; !n runs entry n, !! the last one
+  :help                show this help
+  :quit                leave the session`
+
+// maxLineLength is the longest input line accepted; longer lines end the input with an error
+const maxLineLength = 1 << 20
+
+// errQuit is returned by execute when the user asks to leave
+var errQuit = errors.New("quit")
+
+func main() {
+	stat, err := os.Stdin.Stat()
+	terminal := err == nil && stat.Mode()&os.ModeCharDevice != 0
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr, terminal))
+}
+
+// exprFlags collects repeated -e flags
+type exprFlags []string
+
+func (f *exprFlags) String() string { return strings.Join(*f, "; ") }
+
+func (f *exprFlags) Set(v string) error {
+	*f = append(*f, v)
+	return nil
+}
+
+// run executes the command and returns its exit status
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer, terminal bool) int {
+	fs := flag.NewFlagSet("calc", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var exprs exprFlags
+	fs.Var(&exprs, "e", "evaluate `expression` and print the result (repeatable)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	s := &session{env: calculator.NewEnv(), precision: -1, out: stdout}
+
+	if len(exprs) > 0 {
+		for _, expr := range exprs {
+			if err := s.execute(expr); err != nil && err != errQuit {
+				fmt.Fprintln(stderr, "error:", err)
+				return 1
+			}
+		}
+		return 0
+	}
+
+	if terminal {
+		fmt.Fprintln(stdout, `calc - type ":help" for help`)
+	}
+	var readErr error
+	for input := range inputs(stdin, stdout, terminal, &readErr) {
+		err := s.execute(input)
+		if err == errQuit {
+			break
+		}
+		if err != nil {
+			fmt.Fprintln(stderr, "error:", err)
+			if !terminal {
+				return 1
+			}
+		}
+	}
+	if readErr != nil {
+		fmt.Fprintln(stderr, "error: reading input:", readErr)
+		return 1
+	}
+	return 0
+}
+
+// inputs yields complete inputs read from r, joining continuation lines
+// When prompt is true, a prompt is written to w before each line
+// If reading fails, the error is stored in *err once the inputs read so far are yielded
+func inputs(r io.Reader, w io.Writer, prompt bool, err *error) func(yield func(string) bool) {
+	return func(yield func(string) bool) {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(nil, maxLineLength)
+		defer func() { *err = scanner.Err() }()
+		var buf strings.Builder
+		for {
+			if prompt {
+				if buf.Len() == 0 {
+					fmt.Fprint(w, "> ")
+				} else {
+					fmt.Fprint(w, "... ")
+				}
+			}
+			if !scanner.Scan() {
+				break
+			}
+
+			line := scanner.Text()
+			more := strings.HasSuffix(line, `\`)
+			buf.WriteString(strings.TrimSuffix(line, `\`))
+			if more || parenDepth(buf.String()) > 0 {
+				buf.WriteByte(' ')
+				continue
+			}
+
+			input := strings.TrimSpace(buf.String())
+			buf.Reset()
+			if input != "" && !yield(input) {
+				return
+			}
+		}
+		if input := strings.TrimSpace(buf.String()); input != "" {
+			yield(input)
+		}
+	}
+}
+
+// parenDepth returns the number of unclosed parentheses in s
+func parenDepth(s string) int {
+	return strings.Count(s, "(") - strings.Count(s, ")")
+}
+
+// session is the state of a calculator session
+type session struct {
+	env       *calculator.Env
+	precision int
+	history   []string
+	out       io.Writer
+}
+
+// execute runs a command or evaluates an input and prints the result
+func (s *session) execute(input string) error {
+	if strings.HasPrefix(input, "!") {
+		recalled, err := s.recall(input)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(s.out, recalled)
+		input = recalled
+	}
+	s.history = append(s.history, input)
+
+	if strings.HasPrefix(input, ":") {
+		return s.command(strings.Fields(input[1:]))
+	}
+
+	before := s.env.Funcs()
+	v, err := s.env.Eval(input)
+	if err != nil {
+		return err
+	}
+	if f := changedFunc(before, s.env.Funcs()); f != nil {
+		fmt.Fprintln(s.out, f)
+		return nil
+	}
+	fmt.Fprintln(s.out, s.format(v))
+	return nil
+}
+
+// recall returns the history entry referenced by "!!" or "!n"
+func (s *session) recall(input string) (string, error) {
+	if len(s.history) == 0 {
+		return "", errors.New("history is empty")
+	}
+	if input == "!!" {
+		return s.history[len(s.history)-1], nil
+	}
+	n, err := strconv.Atoi(input[1:])
+	if err != nil || n < 1 || n > len(s.history) {
+		return "", fmt.Errorf("no history entry %q", input)
+	}
+	return s.history[n-1], nil
+}
+
+func (s *session) command(fields []string) error {
+	if len(fields) == 0 {
+		return errors.New(`empty command, type ":help" for help`)
+	}
+	name, args := fields[0], fields[1:]
+
+	switch name {
+	case "help":
+		fmt.Fprintln(s.out, help)
+	case "quit", "q":
+		return errQuit
+	case "vars":
+		vars := s.env.Vars()
+		for _, name := range slices.Sorted(maps.Keys(vars)) {
+			fmt.Fprintf(s.out, "%s = %s\n", name, s.format(vars[name]))
+		}
+		for _, f := range s.env.Funcs() {
+			fmt.Fprintln(s.out, f)
+		}
+	case "history":
+		for i, entry := range s.history[:len(s.history)-1] {
+			fmt.Fprintf(s.out, "%4d  %s\n", i+1, entry)
+		}
+	case "precision":
+		if len(args) == 0 {
+			if s.precision < 0 {
+				fmt.Fprintln(s.out, "auto")
+			} else {
+				fmt.Fprintln(s.out, s.precision)
+			}
+			return nil
+		}
+		if args[0] == "auto" {
+			s.precision = -1
+			return nil
+		}
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 || n > 17 {
+			return fmt.Errorf("precision must be auto or between 1 and 17, got %q", args[0])
+		}
+		s.precision = n
+	case "mode":
+		if len(args) == 0 {
+			fmt.Fprintln(s.out, s.env.Angle)
+			return nil
+		}
+		switch args[0] {
+		case "rad":
+			s.env.Angle = calculator.Radians
+		case "deg":
+			s.env.Angle = calculator.Degrees
+		default:
+			return fmt.Errorf("mode must be rad or deg, got %q", args[0])
+		}
+	default:
+		return fmt.Errorf(`unknown command ":%s", type ":help" for help`, name)
+	}
+	return nil
+}
+
+func (s *session) format(v float64) string {
+	return strconv.FormatFloat(v, 'g', s.precision, 64)
+}
+
+// changedFunc returns the function that differs between two Funcs snapshots, or nil
+func changedFunc(before, after []*calculator.Function) *calculator.Function {
+	for _, f := range after {
+		if !slices.Contains(before, f) {
+			return f
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		stdin    string
+		terminal bool
+		stdout   string
+		stderr   string
+		status   int
+	}{
+		{
+			name:   "expression flags share an environment",
+			args:   []string{"-e", "x = 3", "-e", "x^2"},
+			stdout: "3\n9\n",
+		},
+		{
+			name:   "division by zero in flag",
+			args:   []string{"-e", "1 / 0"},
+			stderr: "error: division by zero\n",
+			status: 1,
+		},
+		{
+			name:   "stdin lines",
+			stdin:  "f(a, b) = a^2 + b\nf(3, 2)\n",
+			stdout: "f(a, b) = a^2 + b\n11\n",
+		},
+		{
+			name:   "multi-line input",
+			stdin:  "max(1,\n  5,\n  3)\n1 + \\\n2\n",
+			stdout: "5\n3\n",
+		},
+		{
+			name:   "stops at first error when not interactive",
+			stdin:  "1 +\n2\n",
+			stderr: "error: unexpected end of expression at column 4\n",
+			status: 1,
+		},
+		{
+			name:     "interactive session continues after errors",
+			stdin:    "1 +\n2\n",
+			terminal: true,
+			stdout:   "calc - type \":help\" for help\n> > 2\n> ",
+			stderr:   "error: unexpected end of expression at column 4\n",
+		},
+		{
+			name:   "precision and mode commands",
+			stdin:  ":precision 3\n1/3\n:mode deg\nsin(90)\n:mode\n",
+			stdout: "0.333\n1\ndeg\n",
+		},
+		{
+			name:   "vars command",
+			stdin:  "b = 2\na = 1\nsq(x) = x * x\n:vars\n",
+			stdout: "2\n1\nsq(x) = x * x\na = 1\nb = 2\nsq(x) = x * x\n",
+		},
+		{
+			name:   "history recall",
+			stdin:  "2 * 21\n:history\n!1\n",
+			stdout: "42\n   1  2 * 21\n2 * 21\n42\n",
+		},
+		{
+			name:   "quit",
+			stdin:  "1\n:quit\n2\n",
+			stdout: "1\n",
+		},
+		{
+			name:   "line longer than the default scanner buffer",
+			stdin:  "1" + strings.Repeat(" ", 100_000) + "+ 1\n",
+			stdout: "2\n",
+		},
+		{
+			name:   "line too long",
+			stdin:  "1\n" + strings.Repeat(" ", maxLineLength+1) + "\n2\n",
+			stdout: "1\n",
+			stderr: "error: reading input: bufio.Scanner: token too long\n",
+			status: 1,
+		},
+		{
+			name:   "unknown command",
+			stdin:  ":foo\n",
+			stderr: "error: unknown command \":foo\", type \":help\" for help\n",
+			status: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			status := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr, tt.terminal)
+			if status != tt.status {
+				t.Errorf("status = %d; want %d", status, tt.status)
+			}
+			if stdout.String() != tt.stdout {
+				t.Errorf("stdout = %q; want %q", stdout.String(), tt.stdout)
+			}
+			if stderr.String() != tt.stderr {
+				t.Errorf("stderr = %q; want %q", stderr.String(), tt.stderr)
+			}
+		})
+	}
+}
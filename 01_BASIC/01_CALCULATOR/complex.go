@@ -0,0 +1,238 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"strconv"
+	"strings"
+)
+
+// ComplexAdd adds two complex numbers and returns the result
+func ComplexAdd(a, b complex128) complex128 {
+	return a + b
+}
+
+// ComplexSubtract subtracts b from a and returns the result
+func ComplexSubtract(a, b complex128) complex128 {
+	return a - b
+}
+
+// ComplexMultiply multiplies two complex numbers and returns the result
+func ComplexMultiply(a, b complex128) complex128 {
+	return a * b
+}
+
+// ComplexDivide divides a by b and returns the result
+// If b is 0, returns ErrDivisionByZero
+func ComplexDivide(a, b complex128) (complex128, error) {
+	if b == 0 {
+		return 0, ErrDivisionByZero
+	}
+	return a / b, nil
+}
+
+// ComplexPower raises base to exponent using the principal branch of the logarithm,
+// so ComplexPower(-8, 1.0/3) is 1+1.732i instead of the NaN returned by Power
+func ComplexPower(base, exponent complex128) complex128 {
+	if exponent == 0 {
+		return 1
+	}
+	if base == 0 {
+		if real(exponent) > 0 {
+			return 0
+		}
+		return cmplx.Inf()
+	}
+	// Integer exponents of real bases stay exact, e.g. (-2)^3 = -8
+	if imag(base) == 0 && imag(exponent) == 0 && real(exponent) == math.Trunc(real(exponent)) {
+		return complex(Power(real(base), real(exponent)), 0)
+	}
+	return cmplx.Pow(base, exponent)
+}
+
+// Root returns the principal n-th root of z
+func Root(z complex128, n int) complex128 {
+	return ComplexPower(z, complex(1/float64(n), 0))
+}
+
+// Roots returns the n distinct n-th roots of z, starting with the principal root
+// and continuing counterclockwise
+func Roots(z complex128, n int) []complex128 {
+	if n <= 0 {
+		return nil
+	}
+	r, theta := cmplx.Polar(z)
+	mod := math.Pow(r, 1/float64(n))
+	roots := make([]complex128, n)
+	for k := range roots {
+		roots[k] = cmplx.Rect(mod, (theta+2*math.Pi*float64(k))/float64(n))
+	}
+	return roots
+}
+
+// Polar returns the modulus r and the argument theta of z, with theta in (-π, π]
+func Polar(z complex128) (r, theta float64) {
+	return cmplx.Polar(z)
+}
+
+// Rect returns the complex number with modulus r and argument theta
+func Rect(r, theta float64) complex128 {
+	return cmplx.Rect(r, theta)
+}
+
+// FormatComplex formats z as "3+4i", "3-4i", "4i" or "3"
+// prec is the number of significant digits, or -1 for the shortest exact form
+func FormatComplex(z complex128, prec int) string {
+	re, im := real(z), imag(z)
+	format := func(v float64) string { return strconv.FormatFloat(v, 'g', prec, 64) }
+
+	// FormatFloat writes +Inf with its sign, so strip it to add the separator only once
+	imText := strings.TrimPrefix(format(im), "+")
+	switch {
+	case im == 0:
+		return format(re)
+	case re == 0:
+		return imText + "i"
+	case im < 0:
+		return format(re) + imText + "i"
+	default:
+		// Positive, +Inf and NaN imaginary parts, e.g. "3+Infi" and "3+NaNi"
+		return format(re) + "+" + imText + "i"
+	}
+}
+
+// ParseComplex parses a complex literal such as "3+4i", "-2.5i", "i", "1e3 - i" or "7"
+func ParseComplex(s string) (complex128, error) {
+	t := strings.Join(strings.Fields(s), "")
+	t = strings.TrimSuffix(strings.TrimPrefix(t, "("), ")")
+	if t == "" {
+		return 0, fmt.Errorf("invalid complex number %q", s)
+	}
+
+	if !strings.HasSuffix(t, "i") {
+		re, err := strconv.ParseFloat(t, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid complex number %q", s)
+		}
+		return complex(re, 0), nil
+	}
+
+	// Split before the sign of the imaginary part, skipping exponent signs such as 1e-3
+	t = strings.TrimSuffix(t, "i")
+	split := 0
+	for i := len(t) - 1; i > 0; i-- {
+		if (t[i] == '+' || t[i] == '-') && t[i-1] != 'e' && t[i-1] != 'E' {
+			split = i
+			break
+		}
+	}
+
+	var re float64
+	if split > 0 {
+		var err error
+		if re, err = strconv.ParseFloat(t[:split], 64); err != nil {
+			return 0, fmt.Errorf("invalid complex number %q", s)
+		}
+	}
+	imText := t[split:]
+	switch imText {
+	case "", "+":
+		imText = "1"
+	case "-":
+		imText = "-1"
+	}
+	im, err := strconv.ParseFloat(imText, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid complex number %q", s)
+	}
+	return complex(re, im), nil
+}
+
+// EvaluateComplex evaluates an expression over complex numbers
+// The name i is the imaginary unit and literals such as 4i are allowed,
+// so "(-8)^(1/3)" and "(3+4i)*(1-2i)" both have results
+func EvaluateComplex(expr string) (complex128, error) {
+	n, err := Parse(expr)
+	if err != nil {
+		return 0, err
+	}
+	return evalComplex(n)
+}
+
+// complexBuiltins are the functions available to EvaluateComplex
+var complexBuiltins = map[string]func(complex128) complex128{
+	"sqrt": cmplx.Sqrt,
+	"exp":  cmplx.Exp,
+	"log":  cmplx.Log,
+	"sin":  cmplx.Sin,
+	"cos":  cmplx.Cos,
+	"abs":  func(z complex128) complex128 { return complex(cmplx.Abs(z), 0) },
+	"conj": cmplx.Conj,
+	"re":   func(z complex128) complex128 { return complex(real(z), 0) },
+	"im":   func(z complex128) complex128 { return complex(imag(z), 0) },
+	"arg":  func(z complex128) complex128 { return complex(cmplx.Phase(z), 0) },
+}
+
+func evalComplex(n Node) (complex128, error) {
+	switch n := n.(type) {
+	case *Number:
+		return complex(n.Value, 0), nil
+	case *Imaginary:
+		return complex(0, n.Value), nil
+	case *Ident:
+		if n.Name == "i" {
+			return 1i, nil
+		}
+		if v, ok := constants[n.Name]; ok {
+			return complex(v, 0), nil
+		}
+		return 0, &UndefinedError{Kind: "variable", Name: n.Name, Col: n.Pos()}
+	case *Call:
+		f, ok := complexBuiltins[n.Name]
+		if !ok {
+			return 0, &UndefinedError{Kind: "function", Name: n.Name, Col: n.Pos()}
+		}
+		if len(n.Args) != 1 {
+			return 0, arityError(n, "1")
+		}
+		z, err := evalComplex(n.Args[0])
+		if err != nil {
+			return 0, err
+		}
+		return f(z), nil
+	case *Unary:
+		z, err := evalComplex(n.X)
+		if err != nil {
+			return 0, err
+		}
+		if n.Op == '-' {
+			// 0 - z instead of -z keeps a zero imaginary part positive,
+			// so (-8)^(1/3) lands on the principal branch
+			return ComplexSubtract(0, z), nil
+		}
+		return z, nil
+	case *Binary:
+		x, err := evalComplex(n.X)
+		if err != nil {
+			return 0, err
+		}
+		y, err := evalComplex(n.Y)
+		if err != nil {
+			return 0, err
+		}
+		switch n.Op {
+		case '+':
+			return ComplexAdd(x, y), nil
+		case '-':
+			return ComplexSubtract(x, y), nil
+		case '*':
+			return ComplexMultiply(x, y), nil
+		case '/':
+			return ComplexDivide(x, y)
+		case '^':
+			return ComplexPower(x, y), nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported node %T", n)
+}
@@ -0,0 +1,189 @@
+package calculator
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func complexClose(a, b complex128) bool {
+	return cmplx.Abs(a-b) < 1e-9
+}
+
+func TestComplexArithmetic(t *testing.T) {
+	a, b := 3+4i, 1-2i
+	if r := ComplexAdd(a, b); r != 4+2i {
+		t.Errorf("ComplexAdd = %v; want (4+2i)", r)
+	}
+	if r := ComplexSubtract(a, b); r != 2+6i {
+		t.Errorf("ComplexSubtract = %v; want (2+6i)", r)
+	}
+	if r := ComplexMultiply(a, b); r != 11-2i {
+		t.Errorf("ComplexMultiply = %v; want (11-2i)", r)
+	}
+	if r, err := ComplexDivide(a, b); err != nil || !complexClose(r, -1+2i) {
+		t.Errorf("ComplexDivide = %v, %v; want (-1+2i)", r, err)
+	}
+	if _, err := ComplexDivide(a, 0); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("ComplexDivide by zero error = %v; want %v", err, ErrDivisionByZero)
+	}
+}
+
+func TestComplexPower(t *testing.T) {
+	tests := []struct {
+		name           string
+		base, exponent complex128
+		expected       complex128
+	}{
+		{"cube root of negative", -8, complex(1.0/3, 0), complex(1, math.Sqrt(3))},
+		{"square root of -1", -1, 0.5, 1i},
+		{"integer exponent stays exact", -2, 3, -8},
+		{"i squared", 1i, 2, -1},
+		{"zero exponent", 0, 0, 1},
+		{"zero base", 0, 2, 0},
+		{"complex exponent", math.E, complex(0, math.Pi), -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if r := ComplexPower(tt.base, tt.exponent); !complexClose(r, tt.expected) {
+				t.Errorf("ComplexPower(%v, %v) = %v; want %v", tt.base, tt.exponent, r, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRoots(t *testing.T) {
+	roots := Roots(-8, 3)
+	expected := []complex128{complex(1, math.Sqrt(3)), -2, complex(1, -math.Sqrt(3))}
+	if len(roots) != len(expected) {
+		t.Fatalf("Roots(-8, 3) returned %d roots; want %d", len(roots), len(expected))
+	}
+	for i := range roots {
+		if !complexClose(roots[i], expected[i]) {
+			t.Errorf("Roots(-8, 3)[%d] = %v; want %v", i, roots[i], expected[i])
+		}
+		if !complexClose(roots[i]*roots[i]*roots[i], -8) {
+			t.Errorf("Roots(-8, 3)[%d]^3 != -8", i)
+		}
+	}
+	if !complexClose(Root(-8, 3), roots[0]) {
+		t.Errorf("Root(-8, 3) = %v; want principal root %v", Root(-8, 3), roots[0])
+	}
+	if Roots(1, 0) != nil {
+		t.Errorf("Roots(1, 0) should be nil")
+	}
+}
+
+func TestPolarRect(t *testing.T) {
+	r, theta := Polar(3 + 4i)
+	if math.Abs(r-5) > 1e-12 || math.Abs(theta-math.Atan2(4, 3)) > 1e-12 {
+		t.Errorf("Polar(3+4i) = %f, %f", r, theta)
+	}
+	if z := Rect(r, theta); !complexClose(z, 3+4i) {
+		t.Errorf("Rect(Polar(3+4i)) = %v", z)
+	}
+	if _, theta := Polar(-1); theta != math.Pi {
+		t.Errorf("Polar(-1) theta = %f; want π", theta)
+	}
+}
+
+func TestFormatComplex(t *testing.T) {
+	tests := []struct {
+		z        complex128
+		prec     int
+		expected string
+	}{
+		{3 + 4i, -1, "3+4i"},
+		{3 - 4i, -1, "3-4i"},
+		{4i, -1, "4i"},
+		{-1i, -1, "-1i"},
+		{3, -1, "3"},
+		{0, -1, "0"},
+		{complex(1.0/3, 2.0/3), 3, "0.333+0.667i"},
+		{complex(3, math.Inf(1)), -1, "3+Infi"},
+		{complex(3, math.Inf(-1)), -1, "3-Infi"},
+		{complex(3, math.NaN()), -1, "3+NaNi"},
+		{complex(0, math.Inf(1)), -1, "Infi"},
+	}
+
+	for _, tt := range tests {
+		if r := FormatComplex(tt.z, tt.prec); r != tt.expected {
+			t.Errorf("FormatComplex(%v, %d) = %q; want %q", tt.z, tt.prec, r, tt.expected)
+		}
+	}
+}
+
+func TestParseComplex(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected complex128
+	}{
+		{"3+4i", 3 + 4i},
+		{"3 - 4i", 3 - 4i},
+		{"-2.5i", -2.5i},
+		{"i", 1i},
+		{"-i", -1i},
+		{"1e3-i", 1000 - 1i},
+		{"1e-3i", 0.001i},
+		{"2.5e+1+1e-1i", 25 + 0.1i},
+		{"(1+2i)", 1 + 2i},
+		{"7", 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			r, err := ParseComplex(tt.input)
+			if err != nil {
+				t.Fatalf("ParseComplex(%q) unexpected error: %v", tt.input, err)
+			}
+			if r != tt.expected {
+				t.Errorf("ParseComplex(%q) = %v; want %v", tt.input, r, tt.expected)
+			}
+			if round, _ := ParseComplex(FormatComplex(r, -1)); round != r {
+				t.Errorf("ParseComplex(FormatComplex(%v)) = %v", r, round)
+			}
+		})
+	}
+
+	for _, invalid := range []string{"", "abc", "3+4j", "3+4ii", "1+2i+3"} {
+		if _, err := ParseComplex(invalid); err == nil {
+			t.Errorf("ParseComplex(%q) expected error", invalid)
+		}
+	}
+}
+
+func TestEvaluateComplex(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected complex128
+	}{
+		{"(-8)^(1/3)", complex(1, math.Sqrt(3))},
+		{"(3+4i)*(1-2i)", 11 - 2i},
+		{"i^2", -1},
+		{"sqrt(-4)", 2i},
+		{"abs(3+4i)", 5},
+		{"conj(2+3i) + re(1+5i) + im(1+5i)", 8 - 3i},
+		{"e^(i*pi) + 1", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			r, err := EvaluateComplex(tt.expr)
+			if err != nil {
+				t.Fatalf("EvaluateComplex(%q) unexpected error: %v", tt.expr, err)
+			}
+			if !complexClose(r, tt.expected) {
+				t.Errorf("EvaluateComplex(%q) = %v; want %v", tt.expr, r, tt.expected)
+			}
+		})
+	}
+
+	if _, err := EvaluateComplex("1 / (i - i)"); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("EvaluateComplex division by zero error = %v; want %v", err, ErrDivisionByZero)
+	}
+	if _, err := Evaluate("2 + 3i"); err == nil {
+		t.Errorf("Evaluate(\"2 + 3i\") expected error for imaginary literal")
+	}
+}
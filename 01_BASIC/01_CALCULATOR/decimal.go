@@ -0,0 +1,221 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Rounding selects how digits beyond the scale of a result are discarded
+type Rounding int
+
+const (
+	// RoundHalfEven rounds to the nearest value, ties to the even digit (banker's rounding)
+	RoundHalfEven Rounding = iota
+	// RoundHalfUp rounds to the nearest value, ties away from zero
+	RoundHalfUp
+	// RoundTruncate drops the extra digits, rounding toward zero
+	RoundTruncate
+)
+
+// Precision is the number of decimal places kept in a result and how to round the rest
+// A negative Scale rounds to tens, hundreds and so on, giving a result with no decimal places
+type Precision struct {
+	Scale    int
+	Rounding Rounding
+}
+
+// Decimal is an arbitrary-precision decimal number equal to coef × 10^-scale
+// The zero value is 0
+type Decimal struct {
+	coef  *big.Int
+	scale int
+}
+
+// NewDecimal creates the decimal unscaled × 10^-scale, e.g. NewDecimal(1999, 2) is 19.99
+func NewDecimal(unscaled int64, scale int) Decimal {
+	return scaled(big.NewInt(unscaled), scale)
+}
+
+// maxDecimalExponent bounds the exponent ParseDecimal accepts, since the exponent
+// becomes a power of ten held exactly in memory
+const maxDecimalExponent = 1_000_000
+
+// ParseDecimal parses a decimal such as "-12.345" or "1.5e3" without any loss of precision
+// Exponents beyond ±1000000 are rejected as out of range
+func ParseDecimal(s string) (Decimal, error) {
+	mantissa, exp := s, 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.Atoi(s[i+1:])
+		if err != nil && !errors.Is(err, strconv.ErrRange) {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+		}
+		if err != nil || e > maxDecimalExponent || e < -maxDecimalExponent {
+			return Decimal{}, fmt.Errorf("exponent out of range in decimal %q", s)
+		}
+		mantissa, exp = s[:i], e
+	}
+
+	intPart, fracPart, _ := strings.Cut(mantissa, ".")
+	digits := strings.TrimLeft(intPart, "+-") + fracPart
+	if digits == "" || strings.Trim(digits, "0123456789") != "" || strings.Count(intPart, "-")+strings.Count(intPart, "+") > 1 {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	if len(intPart) > 0 && (intPart[0] == '-' || intPart[0] == '+') {
+		digits = intPart[:1] + digits
+	}
+
+	coef, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	return scaled(coef, len(fracPart)-exp), nil
+}
+
+// int returns the coefficient, treating the zero value as 0
+func (d Decimal) int() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// Scale returns the number of digits after the decimal point
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of d
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+// Cmp compares d and o and returns -1, 0 or +1
+func (d Decimal) Cmp(o Decimal) int {
+	a, b := align(d, o)
+	return a.Cmp(b)
+}
+
+// Neg returns -d
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: new(big.Int).Neg(d.int()), scale: d.scale}
+}
+
+// Rat returns d as an exact rational number
+func (d Decimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(d.int(), pow10(d.scale))
+}
+
+// Float64 returns the nearest float64 to d
+func (d Decimal) Float64() float64 {
+	f, _ := d.Rat().Float64()
+	return f
+}
+
+// String formats d in plain notation keeping all of its decimal places, e.g. "-0.050"
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.int()).String()
+	sign := ""
+	if d.Sign() < 0 {
+		sign = "-"
+	}
+	if d.scale == 0 {
+		return sign + digits
+	}
+	if len(digits) <= d.scale {
+		digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+	}
+	point := len(digits) - d.scale
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// Round returns d with exactly p.Scale decimal places, rounded with p.Rounding
+func (d Decimal) Round(p Precision) Decimal {
+	if p.Scale >= d.scale {
+		return Decimal{coef: new(big.Int).Mul(d.int(), pow10(p.Scale-d.scale)), scale: p.Scale}
+	}
+	return scaled(roundQuo(d.int(), pow10(d.scale-p.Scale), p.Rounding), p.Scale)
+}
+
+// scaled returns the decimal coef × 10^-scale, moving a negative scale into the coefficient
+// so that the scale of a Decimal is never negative
+func scaled(coef *big.Int, scale int) Decimal {
+	if scale < 0 {
+		return Decimal{coef: coef.Mul(coef, pow10(-scale))}
+	}
+	return Decimal{coef: coef, scale: scale}
+}
+
+// BigAdd returns a + b exactly
+func BigAdd(a, b Decimal) Decimal {
+	x, y := align(a, b)
+	return Decimal{coef: x.Add(x, y), scale: max(a.scale, b.scale)}
+}
+
+// BigSubtract returns a - b exactly
+func BigSubtract(a, b Decimal) Decimal {
+	x, y := align(a, b)
+	return Decimal{coef: x.Sub(x, y), scale: max(a.scale, b.scale)}
+}
+
+// BigMultiply returns a × b exactly
+func BigMultiply(a, b Decimal) Decimal {
+	return Decimal{coef: new(big.Int).Mul(a.int(), b.int()), scale: a.scale + b.scale}
+}
+
+// BigDivide returns a / b rounded to p
+// If b is 0, returns ErrDivisionByZero
+func BigDivide(a, b Decimal, p Precision) (Decimal, error) {
+	if b.Sign() == 0 {
+		return Decimal{}, ErrDivisionByZero
+	}
+	// a/b = (ca × 10^-sa) / (cb × 10^-sb), shifted so the quotient has p.Scale places
+	num := new(big.Int).Mul(a.int(), pow10(max(p.Scale+b.scale-a.scale, 0)))
+	den := new(big.Int).Mul(b.int(), pow10(max(a.scale-b.scale-p.Scale, 0)))
+	return scaled(roundQuo(num, den, p.Rounding), p.Scale), nil
+}
+
+// BigPower returns base raised to an integer exponent, rounded to p
+// A negative exponent divides, so 0 raised to it returns ErrDivisionByZero
+func BigPower(base Decimal, exponent int, p Precision) (Decimal, error) {
+	n := exponent
+	if n < 0 {
+		n = -n
+	}
+	e := big.NewInt(int64(n))
+	result := Decimal{coef: new(big.Int).Exp(base.int(), e, nil), scale: base.scale * n}
+	if exponent < 0 {
+		return BigDivide(NewDecimal(1, 0), result, p)
+	}
+	return result.Round(p), nil
+}
+
+// align returns the coefficients of a and b scaled to their common scale
+func align(a, b Decimal) (*big.Int, *big.Int) {
+	x := new(big.Int).Mul(a.int(), pow10(max(b.scale-a.scale, 0)))
+	y := new(big.Int).Mul(b.int(), pow10(max(a.scale-b.scale, 0)))
+	return x, y
+}
+
+// roundQuo returns num / den rounded to an integer with the given mode
+func roundQuo(num, den *big.Int, mode Rounding) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 || mode == RoundTruncate {
+		return q
+	}
+
+	// Compare the discarded remainder against half of the divisor
+	half := new(big.Int).Abs(r)
+	half.Lsh(half, 1)
+	cmp := half.Cmp(new(big.Int).Abs(den))
+	if cmp > 0 || (cmp == 0 && (mode == RoundHalfUp || q.Bit(0) == 1)) {
+		q.Add(q, big.NewInt(int64(num.Sign()*den.Sign())))
+	}
+	return q
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
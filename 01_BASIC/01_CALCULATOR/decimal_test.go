@@ -0,0 +1,207 @@
+package calculator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func dec(t *testing.T, s string) Decimal {
+	t.Helper()
+	d, err := ParseDecimal(s)
+	if err != nil {
+		t.Fatalf("ParseDecimal(%q) unexpected error: %v", s, err)
+	}
+	return d
+}
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"0", "0"},
+		{"12.345", "12.345"},
+		{"-0.05", "-0.05"},
+		{"+7", "7"},
+		{".5", "0.5"},
+		{"-.5", "-0.5"},
+		{"1.50", "1.50"},
+		{"1.5e3", "1500"},
+		{"25e-4", "0.0025"},
+		{"123456789012345678901234567890.1", "123456789012345678901234567890.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if result := dec(t, tt.input).String(); result != tt.expected {
+				t.Errorf("ParseDecimal(%q) = %s; want %s", tt.input, result, tt.expected)
+			}
+		})
+	}
+
+	for _, invalid := range []string{"", "-", "abc", "1.2.3", "--1", "1-", "1e", "1e1.5"} {
+		if _, err := ParseDecimal(invalid); err == nil {
+			t.Errorf("ParseDecimal(%q) expected error", invalid)
+		}
+	}
+
+	for _, huge := range []string{"1e1000001", "1e-1000001", "1e100000000", "1e99999999999999999999"} {
+		if _, err := ParseDecimal(huge); err == nil || !strings.Contains(err.Error(), "exponent out of range") {
+			t.Errorf("ParseDecimal(%q) error = %v; want exponent out of range", huge, err)
+		}
+	}
+	if d, err := ParseDecimal("1e1000"); err != nil || d.Sign() != 1 {
+		t.Errorf("ParseDecimal(1e1000) = %v, %v; want 10^1000", d, err)
+	}
+}
+
+func TestBigArithmetic(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       func(a, b Decimal) Decimal
+		a, b     string
+		expected string
+	}{
+		{"add exact", BigAdd, "0.1", "0.2", "0.3"},
+		{"add different scales", BigAdd, "1.005", "2", "3.005"},
+		{"subtract", BigSubtract, "0.3", "0.1", "0.2"},
+		{"subtract to negative", BigSubtract, "1", "1.25", "-0.25"},
+		{"multiply", BigMultiply, "1.1", "1.1", "1.21"},
+		{"multiply negative", BigMultiply, "-2.5", "0.4", "-1.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.op(dec(t, tt.a), dec(t, tt.b)).String(); result != tt.expected {
+				t.Errorf("%s(%s, %s) = %s; want %s", tt.name, tt.a, tt.b, result, tt.expected)
+			}
+		})
+	}
+
+	if BigAdd(dec(t, "0.1"), dec(t, "0.2")).Cmp(dec(t, "0.3")) != 0 {
+		t.Errorf("0.1 + 0.2 != 0.3")
+	}
+}
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		input    string
+		scale    int
+		mode     Rounding
+		expected string
+	}{
+		{"2.345", 2, RoundHalfEven, "2.34"},
+		{"2.355", 2, RoundHalfEven, "2.36"},
+		{"2.345", 2, RoundHalfUp, "2.35"},
+		{"-2.345", 2, RoundHalfUp, "-2.35"},
+		{"-2.345", 2, RoundHalfEven, "-2.34"},
+		{"2.349", 2, RoundTruncate, "2.34"},
+		{"-2.349", 2, RoundTruncate, "-2.34"},
+		{"2.3451", 2, RoundHalfEven, "2.35"},
+		{"2.5", 0, RoundHalfEven, "2"},
+		{"3.5", 0, RoundHalfEven, "4"},
+		{"1.5", 3, RoundHalfEven, "1.500"},
+		{"1250", -2, RoundHalfEven, "1200"},
+		{"1250.01", -2, RoundHalfEven, "1300"},
+		{"-1234.5", -1, RoundTruncate, "-1230"},
+		{"49.9", -2, RoundHalfUp, "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := dec(t, tt.input).Round(Precision{Scale: tt.scale, Rounding: tt.mode}).String()
+			if result != tt.expected {
+				t.Errorf("Round(%s, %d, %d) = %s; want %s", tt.input, tt.scale, tt.mode, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBigDivide(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		p        Precision
+		expected string
+	}{
+		{"1", "3", Precision{Scale: 4}, "0.3333"},
+		{"2", "3", Precision{Scale: 4, Rounding: RoundHalfEven}, "0.6667"},
+		{"2", "3", Precision{Scale: 4, Rounding: RoundTruncate}, "0.6666"},
+		{"-1", "8", Precision{Scale: 2, Rounding: RoundHalfEven}, "-0.12"},
+		{"-1", "8", Precision{Scale: 2, Rounding: RoundHalfUp}, "-0.13"},
+		{"100.00", "0.25", Precision{Scale: 0}, "400"},
+		{"1.23456", "1", Precision{Scale: 2}, "1.23"},
+		{"10", "4", Precision{Scale: 3}, "2.500"},
+		{"12345", "10", Precision{Scale: -1}, "1230"},
+		{"1000", "3", Precision{Scale: -2}, "300"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			result, err := BigDivide(dec(t, tt.a), dec(t, tt.b), tt.p)
+			if err != nil {
+				t.Fatalf("BigDivide unexpected error: %v", err)
+			}
+			if result.String() != tt.expected {
+				t.Errorf("BigDivide(%s, %s) = %s; want %s", tt.a, tt.b, result, tt.expected)
+			}
+		})
+	}
+
+	if d, _ := BigDivide(dec(t, "1000"), dec(t, "3"), Precision{Scale: -2}); d.Scale() != 0 {
+		t.Errorf("BigDivide with a negative scale has Scale() = %d; want 0", d.Scale())
+	}
+
+	_, err := BigDivide(dec(t, "1"), dec(t, "0.00"), Precision{Scale: 2})
+	_, divideErr := Divide(1, 0)
+	if !errors.Is(err, ErrDivisionByZero) || err.Error() != divideErr.Error() {
+		t.Errorf("BigDivide by zero error = %v; want %v", err, divideErr)
+	}
+}
+
+func TestBigPower(t *testing.T) {
+	tests := []struct {
+		base     string
+		exponent int
+		p        Precision
+		expected string
+	}{
+		{"2", 10, Precision{}, "1024"},
+		{"1.1", 2, Precision{Scale: 2}, "1.21"},
+		{"1.01", 12, Precision{Scale: 6}, "1.126825"},
+		{"-1.5", 3, Precision{Scale: 3}, "-3.375"},
+		{"2", -2, Precision{Scale: 2}, "0.25"},
+		{"3", -1, Precision{Scale: 5, Rounding: RoundTruncate}, "0.33333"},
+		{"5", 0, Precision{}, "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.base, func(t *testing.T) {
+			result, err := BigPower(dec(t, tt.base), tt.exponent, tt.p)
+			if err != nil {
+				t.Fatalf("BigPower unexpected error: %v", err)
+			}
+			if result.String() != tt.expected {
+				t.Errorf("BigPower(%s, %d) = %s; want %s", tt.base, tt.exponent, result, tt.expected)
+			}
+		})
+	}
+
+	if _, err := BigPower(Decimal{}, -1, Precision{}); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("BigPower(0, -1) error = %v; want %v", err, ErrDivisionByZero)
+	}
+}
+
+func TestDecimalConversions(t *testing.T) {
+	d := NewDecimal(1999, 2)
+	if d.String() != "19.99" || d.Scale() != 2 || d.Float64() != 19.99 {
+		t.Errorf("NewDecimal(1999, 2) = %s (scale %d, float %f)", d, d.Scale(), d.Float64())
+	}
+	if s := NewDecimal(5, -3).String(); s != "5000" {
+		t.Errorf("NewDecimal(5, -3) = %s; want 5000", s)
+	}
+	var zero Decimal
+	if zero.String() != "0" || zero.Sign() != 0 || zero.Neg().String() != "0" {
+		t.Errorf("zero Decimal = %s", zero)
+	}
+}
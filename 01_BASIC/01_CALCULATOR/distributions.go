@@ -0,0 +1,463 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+)
+
+// Distribution is a probability distribution over the real numbers
+// For discrete distributions PDF is the probability mass, and 0 away from the integers
+type Distribution interface {
+	PDF(x float64) float64
+	CDF(x float64) float64
+	// Quantile returns the smallest x with CDF(x) >= p, for p in [0, 1]
+	Quantile(p float64) (float64, error)
+	Mean() float64
+	Variance() float64
+}
+
+func checkProbability(p float64) error {
+	if p < 0 || p > 1 || math.IsNaN(p) {
+		return fmt.Errorf("probability must be between 0 and 1, got %g", p)
+	}
+	return nil
+}
+
+// Normal is the normal distribution with mean Mu and standard deviation Sigma
+type Normal struct {
+	Mu, Sigma float64
+}
+
+// NewNormal returns the normal distribution with mean mu and standard deviation sigma > 0
+func NewNormal(mu, sigma float64) (Normal, error) {
+	if !(sigma > 0) {
+		return Normal{}, fmt.Errorf("normal: standard deviation must be positive, got %g", sigma)
+	}
+	return Normal{Mu: mu, Sigma: sigma}, nil
+}
+
+// PDF returns the probability density at x
+func (d Normal) PDF(x float64) float64 {
+	z := (x - d.Mu) / d.Sigma
+	return math.Exp(-z*z/2) / (d.Sigma * math.Sqrt(2*math.Pi))
+}
+
+// CDF returns the probability of a value less than or equal to x
+func (d Normal) CDF(x float64) float64 {
+	return math.Erfc(-(x-d.Mu)/(d.Sigma*math.Sqrt2)) / 2
+}
+
+// Quantile returns the value below which a fraction p of the distribution lies
+func (d Normal) Quantile(p float64) (float64, error) {
+	if err := checkProbability(p); err != nil {
+		return 0, err
+	}
+	return d.Mu - d.Sigma*math.Sqrt2*math.Erfcinv(2*p), nil
+}
+
+// Mean returns the expected value
+func (d Normal) Mean() float64 {
+	return d.Mu
+}
+
+// Variance returns the variance
+func (d Normal) Variance() float64 {
+	return d.Sigma * d.Sigma
+}
+
+// Binomial is the number of successes in N independent trials with success probability P
+type Binomial struct {
+	N int
+	P float64
+}
+
+// NewBinomial returns the binomial distribution of n >= 0 trials with success probability p
+func NewBinomial(n int, p float64) (Binomial, error) {
+	if n < 0 {
+		return Binomial{}, fmt.Errorf("binomial: number of trials must not be negative, got %d", n)
+	}
+	if err := checkProbability(p); err != nil {
+		return Binomial{}, fmt.Errorf("binomial: %w", err)
+	}
+	return Binomial{N: n, P: p}, nil
+}
+
+// PDF returns the probability of exactly x successes
+func (d Binomial) PDF(x float64) float64 {
+	if x != math.Trunc(x) || x < 0 || x > float64(d.N) {
+		return 0
+	}
+	n, k := float64(d.N), x
+	switch d.P {
+	case 0:
+		return boolFloat(k == 0)
+	case 1:
+		return boolFloat(k == n)
+	}
+	return math.Exp(lchoose(n, k) + k*math.Log(d.P) + (n-k)*math.Log1p(-d.P))
+}
+
+// CDF returns the probability of a value less than or equal to x
+func (d Binomial) CDF(x float64) float64 {
+	k := math.Floor(x)
+	switch {
+	case k < 0:
+		return 0
+	case k >= float64(d.N):
+		return 1
+	}
+	// P(X <= k) = I_{1-p}(n-k, k+1)
+	return RegularizedBeta(float64(d.N)-k, k+1, 1-d.P)
+}
+
+// Quantile returns the value below which a fraction p of the distribution lies
+func (d Binomial) Quantile(p float64) (float64, error) {
+	if err := checkProbability(p); err != nil {
+		return 0, err
+	}
+	return discreteQuantile(d.CDF, p, float64(d.N)), nil
+}
+
+// Mean returns the expected value
+func (d Binomial) Mean() float64 {
+	return float64(d.N) * d.P
+}
+
+// Variance returns the variance
+func (d Binomial) Variance() float64 {
+	return float64(d.N) * d.P * (1 - d.P)
+}
+
+// Poisson is the number of events in an interval with an average rate of Lambda
+type Poisson struct {
+	Lambda float64
+}
+
+// NewPoisson returns the Poisson distribution with mean lambda > 0
+func NewPoisson(lambda float64) (Poisson, error) {
+	if !(lambda > 0) || math.IsInf(lambda, 1) {
+		return Poisson{}, fmt.Errorf("poisson: rate must be positive, got %g", lambda)
+	}
+	return Poisson{Lambda: lambda}, nil
+}
+
+// PDF returns the probability of exactly x events
+func (d Poisson) PDF(x float64) float64 {
+	if x != math.Trunc(x) || x < 0 {
+		return 0
+	}
+	lg, _ := math.Lgamma(x + 1)
+	return math.Exp(x*math.Log(d.Lambda) - d.Lambda - lg)
+}
+
+// CDF returns the probability of a value less than or equal to x
+func (d Poisson) CDF(x float64) float64 {
+	k := math.Floor(x)
+	if k < 0 {
+		return 0
+	}
+	// P(X <= k) = Q(k+1, λ)
+	return RegularizedGammaQ(k+1, d.Lambda)
+}
+
+// Quantile returns the value below which a fraction p of the distribution lies
+func (d Poisson) Quantile(p float64) (float64, error) {
+	if err := checkProbability(p); err != nil {
+		return 0, err
+	}
+	if p == 1 {
+		return math.Inf(1), nil
+	}
+	return discreteQuantile(d.CDF, p, math.Inf(1)), nil
+}
+
+// Mean returns the expected value
+func (d Poisson) Mean() float64 {
+	return d.Lambda
+}
+
+// Variance returns the variance
+func (d Poisson) Variance() float64 {
+	return d.Lambda
+}
+
+// Exponential is the waiting time between events that occur at an average Rate
+type Exponential struct {
+	Rate float64
+}
+
+// NewExponential returns the exponential distribution with rate > 0
+func NewExponential(rate float64) (Exponential, error) {
+	if !(rate > 0) {
+		return Exponential{}, fmt.Errorf("exponential: rate must be positive, got %g", rate)
+	}
+	return Exponential{Rate: rate}, nil
+}
+
+// PDF returns the probability density at x
+func (d Exponential) PDF(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	return d.Rate * math.Exp(-d.Rate*x)
+}
+
+// CDF returns the probability of a value less than or equal to x
+func (d Exponential) CDF(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	return -math.Expm1(-d.Rate * x)
+}
+
+// Quantile returns the value below which a fraction p of the distribution lies
+func (d Exponential) Quantile(p float64) (float64, error) {
+	if err := checkProbability(p); err != nil {
+		return 0, err
+	}
+	return -math.Log1p(-p) / d.Rate, nil
+}
+
+// Mean returns the expected value
+func (d Exponential) Mean() float64 {
+	return 1 / d.Rate
+}
+
+// Variance returns the variance
+func (d Exponential) Variance() float64 {
+	return 1 / (d.Rate * d.Rate)
+}
+
+// StudentT is Student's t distribution with Nu degrees of freedom
+type StudentT struct {
+	Nu float64
+}
+
+// NewStudentT returns Student's t distribution with nu > 0 degrees of freedom
+func NewStudentT(nu float64) (StudentT, error) {
+	if !(nu > 0) {
+		return StudentT{}, fmt.Errorf("student t: degrees of freedom must be positive, got %g", nu)
+	}
+	return StudentT{Nu: nu}, nil
+}
+
+// PDF returns the probability density at x
+func (d StudentT) PDF(x float64) float64 {
+	a, _ := math.Lgamma((d.Nu + 1) / 2)
+	b, _ := math.Lgamma(d.Nu / 2)
+	return math.Exp(a-b-(d.Nu+1)/2*math.Log1p(x*x/d.Nu)) / math.Sqrt(d.Nu*math.Pi)
+}
+
+// CDF returns the probability of a value less than or equal to x
+func (d StudentT) CDF(x float64) float64 {
+	// Each tail is I_{ν/(ν+x²)}(ν/2, 1/2) / 2
+	tail := RegularizedBeta(d.Nu/2, 0.5, d.Nu/(d.Nu+x*x)) / 2
+	if x > 0 {
+		return 1 - tail
+	}
+	return tail
+}
+
+// Quantile returns the value below which a fraction p of the distribution lies
+func (d StudentT) Quantile(p float64) (float64, error) {
+	if err := checkProbability(p); err != nil {
+		return 0, err
+	}
+	switch p {
+	case 0:
+		return math.Inf(-1), nil
+	case 0.5:
+		return 0, nil
+	case 1:
+		return math.Inf(1), nil
+	}
+	// Solve CDF(x) = p over a bracket that grows until it contains the quantile
+	f := func(x float64) float64 { return d.CDF(x) - p }
+	hi := 1.0
+	for f(hi) < 0 || f(-hi) > 0 {
+		hi *= 2
+	}
+	r, err := Brent(f, -hi, hi, Options{Tolerance: 1e-13})
+	return r.Value, err
+}
+
+// Mean returns the expected value, which is undefined (NaN) for Nu <= 1
+func (d StudentT) Mean() float64 {
+	if d.Nu > 1 {
+		return 0
+	}
+	return math.NaN()
+}
+
+// Variance returns the variance, infinite for 1 < Nu <= 2 and undefined (NaN) below
+func (d StudentT) Variance() float64 {
+	switch {
+	case d.Nu > 2:
+		return d.Nu / (d.Nu - 2)
+	case d.Nu > 1:
+		return math.Inf(1)
+	}
+	return math.NaN()
+}
+
+// discreteQuantile returns the smallest integer k in [0, hi] with cdf(k) >= p
+func discreteQuantile(cdf func(float64) float64, p, hi float64) float64 {
+	lo := 0.0
+	if cdf(lo) >= p {
+		return lo
+	}
+	// Grow the upper bound for unbounded support, then bisect over the integers
+	upper := 1.0
+	for upper < hi && cdf(upper) < p {
+		lo, upper = upper, upper*2
+	}
+	upper = math.Min(upper, hi)
+	for upper-lo > 1 {
+		mid := math.Floor((lo + upper) / 2)
+		if cdf(mid) >= p {
+			upper = mid
+		} else {
+			lo = mid
+		}
+	}
+	return upper
+}
+
+func lchoose(n, k float64) float64 {
+	a, _ := math.Lgamma(n + 1)
+	b, _ := math.Lgamma(k + 1)
+	c, _ := math.Lgamma(n - k + 1)
+	return a - b - c
+}
+
+func boolFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// RegularizedBeta returns the regularized incomplete beta function I_x(a, b) for a, b > 0 and x in [0, 1]
+// It is evaluated with Lentz's continued fraction, using the symmetry I_x(a, b) = 1 - I_{1-x}(b, a)
+// where the fraction converges faster
+func RegularizedBeta(a, b, x float64) float64 {
+	switch {
+	case math.IsNaN(x) || x < 0 || x > 1 || !(a > 0) || !(b > 0):
+		return math.NaN()
+	case x == 0:
+		return 0
+	case x == 1:
+		return 1
+	}
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	front := math.Exp(lab - la - lb + a*math.Log(x) + b*math.Log1p(-x))
+	if x < (a+1)/(a+b+2) {
+		return front * betaFraction(a, b, x) / a
+	}
+	return 1 - front*betaFraction(b, a, 1-x)/b
+}
+
+// betaFraction evaluates the continued fraction of the incomplete beta function
+func betaFraction(a, b, x float64) float64 {
+	const tiny = 1e-300
+	c, d := 1.0, 1-(a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+	for m := 1.0; m <= 300; m++ {
+		// Even and odd steps of the fraction
+		for _, coef := range []float64{
+			m * (b - m) * x / ((a + 2*m - 1) * (a + 2*m)),
+			-(a + m) * (a + b + m) * x / ((a + 2*m) * (a + 2*m + 1)),
+		} {
+			d = 1 + coef*d
+			if math.Abs(d) < tiny {
+				d = tiny
+			}
+			c = 1 + coef/c
+			if math.Abs(c) < tiny {
+				c = tiny
+			}
+			d = 1 / d
+			h *= d * c
+		}
+		if math.Abs(d*c-1) < 1e-15 {
+			break
+		}
+	}
+	return h
+}
+
+// RegularizedGammaP returns the regularized lower incomplete gamma function P(a, x) for a > 0 and x >= 0
+func RegularizedGammaP(a, x float64) float64 {
+	switch {
+	case math.IsNaN(x) || x < 0 || !(a > 0):
+		return math.NaN()
+	case x == 0:
+		return 0
+	case x < a+1:
+		return gammaSeries(a, x)
+	}
+	return 1 - gammaFraction(a, x)
+}
+
+// RegularizedGammaQ returns the regularized upper incomplete gamma function Q(a, x) = 1 - P(a, x),
+// computed directly so that small upper tails keep their precision
+func RegularizedGammaQ(a, x float64) float64 {
+	switch {
+	case math.IsNaN(x) || x < 0 || !(a > 0):
+		return math.NaN()
+	case x == 0:
+		return 1
+	case x < a+1:
+		return 1 - gammaSeries(a, x)
+	}
+	return gammaFraction(a, x)
+}
+
+// gammaSeries evaluates P(a, x) with its power series, which converges quickly for x < a+1
+func gammaSeries(a, x float64) float64 {
+	lg, _ := math.Lgamma(a)
+	term := 1 / a
+	sum := term
+	for n := 1.0; n <= 1000; n++ {
+		term *= x / (a + n)
+		sum += term
+		if math.Abs(term) < math.Abs(sum)*1e-16 {
+			break
+		}
+	}
+	return sum * math.Exp(-x+a*math.Log(x)-lg)
+}
+
+// gammaFraction evaluates Q(a, x) with Lentz's continued fraction, which converges quickly for x >= a+1
+func gammaFraction(a, x float64) float64 {
+	const tiny = 1e-300
+	lg, _ := math.Lgamma(a)
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for n := 1.0; n <= 1000; n++ {
+		an := -n * (n - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		h *= d * c
+		if math.Abs(d*c-1) < 1e-15 {
+			break
+		}
+	}
+	return math.Exp(-x+a*math.Log(x)-lg) * h
+}
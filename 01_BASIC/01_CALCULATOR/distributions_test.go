@@ -0,0 +1,177 @@
+package calculator
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistributions(t *testing.T) {
+	normal, _ := NewNormal(0, 1)
+	shifted, _ := NewNormal(10, 2)
+	binomial, _ := NewBinomial(10, 0.5)
+	poisson, _ := NewPoisson(3)
+	exponential, _ := NewExponential(2)
+	t10, _ := NewStudentT(10)
+	cauchy, _ := NewStudentT(1)
+
+	tests := []struct {
+		name     string
+		fn       func() float64
+		expected float64
+	}{
+		{"normal pdf", func() float64 { return normal.PDF(0) }, 1 / math.Sqrt(2*math.Pi)},
+		{"normal cdf", func() float64 { return normal.CDF(1.96) }, 0.9750021048517795},
+		{"normal cdf tail", func() float64 { return normal.CDF(-10) }, 7.61985302416047e-24},
+		{"shifted normal cdf", func() float64 { return shifted.CDF(12) }, 0.8413447460685429},
+		{"binomial pmf", func() float64 { return binomial.PDF(5) }, 252.0 / 1024},
+		{"binomial pmf non-integer", func() float64 { return binomial.PDF(2.5) }, 0},
+		{"binomial cdf", func() float64 { return binomial.CDF(5) }, 638.0 / 1024},
+		{"binomial cdf between integers", func() float64 { return binomial.CDF(5.7) }, 638.0 / 1024},
+		{"poisson pmf", func() float64 { return poisson.PDF(2) }, 4.5 * math.Exp(-3)},
+		{"poisson cdf", func() float64 { return poisson.CDF(2) }, 8.5 * math.Exp(-3)},
+		{"exponential pdf", func() float64 { return exponential.PDF(1) }, 2 * math.Exp(-2)},
+		{"exponential cdf", func() float64 { return exponential.CDF(1) }, 1 - math.Exp(-2)},
+		{"student t cdf", func() float64 { return t10.CDF(2.228138851986274) }, 0.975},
+		{"student t symmetric", func() float64 { return t10.CDF(-1) + t10.CDF(1) }, 1},
+		{"cauchy cdf", func() float64 { return cauchy.CDF(1) }, 0.75},
+		{"cauchy pdf", func() float64 { return cauchy.PDF(0) }, 1 / math.Pi},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fn(); math.Abs(got-tt.expected) > 1e-12*math.Max(1, math.Abs(tt.expected)) && math.Abs(got/tt.expected-1) > 1e-9 {
+				t.Errorf("got %v; want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestQuantiles(t *testing.T) {
+	normal, _ := NewNormal(0, 1)
+	binomial, _ := NewBinomial(10, 0.5)
+	poisson, _ := NewPoisson(3)
+	largePoisson, _ := NewPoisson(1000)
+	exponential, _ := NewExponential(2)
+	t10, _ := NewStudentT(10)
+	cauchy, _ := NewStudentT(1)
+
+	tests := []struct {
+		name     string
+		d        Distribution
+		p        float64
+		expected float64
+	}{
+		{"normal", normal, 0.975, 1.959963984540054},
+		{"normal median", normal, 0.5, 0},
+		{"normal lower", normal, 0.025, -1.959963984540054},
+		{"binomial", binomial, 0.5, 5},
+		{"binomial zero", binomial, 0, 0},
+		{"binomial one", binomial, 1, 10},
+		{"poisson", poisson, 0.5, 3},
+		{"poisson zero", poisson, 0.01, 0},
+		{"large poisson", largePoisson, 0.5, 1000},
+		{"exponential", exponential, 0.5, math.Ln2 / 2},
+		{"student t", t10, 0.975, 2.228138851986274},
+		{"student t lower", t10, 0.025, -2.228138851986274},
+		{"cauchy", cauchy, 0.975, 12.706204736174705},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.d.Quantile(tt.p)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if math.Abs(got-tt.expected) > 1e-9*math.Max(1, math.Abs(tt.expected)) {
+				t.Errorf("Quantile(%v) = %v; want %v", tt.p, got, tt.expected)
+			}
+		})
+	}
+
+	for _, d := range []Distribution{normal, binomial, poisson, exponential, t10} {
+		if _, err := d.Quantile(1.5); err == nil {
+			t.Errorf("%T.Quantile(1.5) expected error", d)
+		}
+	}
+}
+
+func TestDiscreteQuantileIsSmallest(t *testing.T) {
+	binomial, _ := NewBinomial(30, 0.3)
+	poisson, _ := NewPoisson(7.5)
+	for _, d := range []Distribution{binomial, poisson} {
+		for _, p := range []float64{0.05, 0.25, 0.5, 0.75, 0.95, 0.999} {
+			k, _ := d.Quantile(p)
+			if d.CDF(k) < p || d.CDF(k-1) >= p {
+				t.Errorf("%T.Quantile(%v) = %v with CDF(k) = %v and CDF(k-1) = %v", d, p, k, d.CDF(k), d.CDF(k-1))
+			}
+		}
+	}
+}
+
+func TestDistributionMoments(t *testing.T) {
+	binomial, _ := NewBinomial(10, 0.3)
+	t5, _ := NewStudentT(5)
+	cauchy, _ := NewStudentT(1)
+	exponential, _ := NewExponential(4)
+
+	if binomial.Mean() != 3 || math.Abs(binomial.Variance()-2.1) > 1e-12 {
+		t.Errorf("binomial mean %v, variance %v; want 3, 2.1", binomial.Mean(), binomial.Variance())
+	}
+	if exponential.Mean() != 0.25 || exponential.Variance() != 0.0625 {
+		t.Errorf("exponential mean %v, variance %v; want 0.25, 0.0625", exponential.Mean(), exponential.Variance())
+	}
+	if t5.Mean() != 0 || t5.Variance() != 5.0/3 || !math.IsNaN(cauchy.Mean()) {
+		t.Errorf("student t moments: %v, %v, %v", t5.Mean(), t5.Variance(), cauchy.Mean())
+	}
+}
+
+func TestDistributionParameters(t *testing.T) {
+	if _, err := NewNormal(0, 0); err == nil {
+		t.Errorf("NewNormal with zero sigma expected error")
+	}
+	if _, err := NewBinomial(-1, 0.5); err == nil {
+		t.Errorf("NewBinomial with negative trials expected error")
+	}
+	if _, err := NewBinomial(10, 1.5); err == nil {
+		t.Errorf("NewBinomial with probability 1.5 expected error")
+	}
+	if _, err := NewPoisson(0); err == nil {
+		t.Errorf("NewPoisson with zero rate expected error")
+	}
+	if _, err := NewExponential(-1); err == nil {
+		t.Errorf("NewExponential with negative rate expected error")
+	}
+	if _, err := NewStudentT(math.NaN()); err == nil {
+		t.Errorf("NewStudentT with NaN degrees of freedom expected error")
+	}
+}
+
+func TestIncompleteFunctions(t *testing.T) {
+	tests := []struct {
+		name     string
+		got      float64
+		expected float64
+	}{
+		{"beta small x", RegularizedBeta(2, 3, 0.4), 0.5248},
+		{"beta large x", RegularizedBeta(5, 2, 0.9), 0.885735},
+		{"beta power", RegularizedBeta(2.5, 1, 0.3), math.Pow(0.3, 2.5)},
+		{"beta edges", RegularizedBeta(2, 3, 0) + RegularizedBeta(2, 3, 1), 1},
+		{"gamma exponential", RegularizedGammaP(1, 2), 1 - math.Exp(-2)},
+		{"gamma series", RegularizedGammaP(3, 1), 1 - 2.5*math.Exp(-1)},
+		{"gamma complement", RegularizedGammaQ(3, 10), 61 * math.Exp(-10)},
+		{"gamma tail", RegularizedGammaQ(1, 50), math.Exp(-50)},
+		{"gamma sum", RegularizedGammaP(4.5, 7) + RegularizedGammaQ(4.5, 7), 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if math.Abs(tt.got-tt.expected) > 1e-13 && math.Abs(tt.got/tt.expected-1) > 1e-12 {
+				t.Errorf("got %v; want %v", tt.got, tt.expected)
+			}
+		})
+	}
+
+	if !math.IsNaN(RegularizedBeta(-1, 2, 0.5)) || !math.IsNaN(RegularizedGammaP(1, -1)) {
+		t.Errorf("invalid arguments should return NaN")
+	}
+}
@@ -0,0 +1,282 @@
+package calculator
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+)
+
+// UndefinedError reports a reference to a name that has no definition
+// Kind is "variable" or "function"
+type UndefinedError struct {
+	Kind string
+	Name string
+	Col  int
+}
+
+func (e *UndefinedError) Error() string {
+	return fmt.Sprintf("undefined %s '%s' at column %d", e.Kind, e.Name, e.Col)
+}
+
+// RecursionError reports a function definition that would call itself
+// Chain lists the calls that lead back to the function, e.g. [f g f]
+type RecursionError struct {
+	Chain []string
+}
+
+func (e *RecursionError) Error() string {
+	return "recursive definition: " + strings.Join(e.Chain, " -> ")
+}
+
+// Function is a user-defined function such as f(a, b) = a^2 + b
+type Function struct {
+	Name   string
+	Params []string
+	Body   Node
+}
+
+func (f *Function) String() string {
+	return fmt.Sprintf("%s(%s) = %s", f.Name, strings.Join(f.Params, ", "), f.Body)
+}
+
+// AngleUnit selects how trigonometric functions interpret their arguments
+type AngleUnit int
+
+const (
+	Radians AngleUnit = iota
+	Degrees
+)
+
+func (u AngleUnit) String() string {
+	if u == Degrees {
+		return "deg"
+	}
+	return "rad"
+}
+
+// Env is an evaluation environment holding variables and user-defined functions
+// Angle sets the unit used by sin and cos, radians by default
+type Env struct {
+	Angle AngleUnit
+	vars  map[string]float64
+	funcs map[string]*Function
+}
+
+// NewEnv creates an empty environment
+func NewEnv() *Env {
+	return &Env{
+		vars:  map[string]float64{},
+		funcs: map[string]*Function{},
+	}
+}
+
+// Eval evaluates one line of input
+// "x = 3" assigns a variable and returns its value
+// "f(a, b) = a^2 + b" defines a function and returns 0
+// Anything else is evaluated as an expression
+func (e *Env) Eval(input string) (float64, error) {
+	st, err := parseStatement(input)
+	if err != nil {
+		return 0, err
+	}
+
+	if st.params != nil {
+		return 0, e.define(&Function{Name: st.name, Params: st.params, Body: st.body})
+	}
+
+	v, err := evaluator{env: e}.eval(st.body)
+	if err != nil {
+		return 0, err
+	}
+	if st.name != "" {
+		if err := e.Set(st.name, v); err != nil {
+			return 0, err
+		}
+	}
+	return v, nil
+}
+
+// Get returns the value of a variable
+func (e *Env) Get(name string) (float64, bool) {
+	v, ok := e.vars[name]
+	return v, ok
+}
+
+// Set assigns a value to a variable
+// Returns an error if name is a built-in constant
+func (e *Env) Set(name string, value float64) error {
+	if _, ok := constants[name]; ok {
+		return fmt.Errorf("cannot assign to constant '%s'", name)
+	}
+	e.vars[name] = value
+	return nil
+}
+
+// Define parses body and defines the function name(params...)
+// Returns a *RecursionError if the function would end up calling itself
+func (e *Env) Define(name string, params []string, body string) error {
+	n, err := Parse(body)
+	if err != nil {
+		return err
+	}
+	return e.define(&Function{Name: name, Params: params, Body: n})
+}
+
+// Vars returns a copy of the variables in the environment
+func (e *Env) Vars() map[string]float64 {
+	return maps.Clone(e.vars)
+}
+
+// Funcs returns the user-defined functions sorted by name
+func (e *Env) Funcs() []*Function {
+	funcs := make([]*Function, 0, len(e.funcs))
+	for _, name := range slices.Sorted(maps.Keys(e.funcs)) {
+		funcs = append(funcs, e.funcs[name])
+	}
+	return funcs
+}
+
+func (e *Env) define(f *Function) error {
+	if _, ok := builtins[f.Name]; ok {
+		return fmt.Errorf("cannot redefine built-in function '%s'", f.Name)
+	}
+	for i, p := range f.Params {
+		if slices.Contains(f.Params[:i], p) {
+			return fmt.Errorf("duplicate parameter '%s' in function '%s'", p, f.Name)
+		}
+	}
+	if chain := e.findCycle(f.Name, f.Body); chain != nil {
+		return &RecursionError{Chain: chain}
+	}
+	e.funcs[f.Name] = f
+	return nil
+}
+
+// findCycle returns the chain of calls from body back to name, or nil if there is none
+func (e *Env) findCycle(name string, body Node) []string {
+	visited := map[string]bool{}
+
+	var search func(chain []string, body Node) []string
+	search = func(chain []string, body Node) []string {
+		for _, callee := range calledNames(body) {
+			next := append(chain[:len(chain):len(chain)], callee)
+			if callee == name {
+				return next
+			}
+			f, ok := e.funcs[callee]
+			if !ok || visited[callee] {
+				continue
+			}
+			visited[callee] = true
+			if cycle := search(next, f.Body); cycle != nil {
+				return cycle
+			}
+		}
+		return nil
+	}
+	return search([]string{name}, body)
+}
+
+// calledNames returns the names of the functions called anywhere in n
+func calledNames(n Node) []string {
+	var names []string
+	switch n := n.(type) {
+	case *Call:
+		names = append(names, n.Name)
+		for _, arg := range n.Args {
+			names = append(names, calledNames(arg)...)
+		}
+	case *Unary:
+		names = calledNames(n.X)
+	case *Binary:
+		names = append(calledNames(n.X), calledNames(n.Y)...)
+	}
+	return names
+}
+
+// savedEnv is the JSON representation of an Env
+type savedEnv struct {
+	Angle AngleUnit             `json:"angle,omitempty"`
+	Vars  map[string]savedFloat `json:"vars"`
+	Funcs []savedFunc           `json:"funcs"`
+}
+
+// savedFloat is a float64 that JSON can hold even when it is not finite:
+// infinities and NaN, which results such as log(0) produce, are saved as "Inf", "-Inf" and "NaN"
+type savedFloat float64
+
+func (f savedFloat) MarshalJSON() ([]byte, error) {
+	switch v := float64(f); {
+	case math.IsNaN(v):
+		return []byte(`"NaN"`), nil
+	case math.IsInf(v, 1):
+		return []byte(`"Inf"`), nil
+	case math.IsInf(v, -1):
+		return []byte(`"-Inf"`), nil
+	}
+	return json.Marshal(float64(f))
+}
+
+func (f *savedFloat) UnmarshalJSON(data []byte) error {
+	var name string
+	if json.Unmarshal(data, &name) != nil {
+		return json.Unmarshal(data, (*float64)(f))
+	}
+	switch name {
+	case "NaN":
+		*f = savedFloat(math.NaN())
+	case "Inf":
+		*f = savedFloat(math.Inf(1))
+	case "-Inf":
+		*f = savedFloat(math.Inf(-1))
+	default:
+		return fmt.Errorf("invalid number %q", name)
+	}
+	return nil
+}
+
+type savedFunc struct {
+	Name   string   `json:"name"`
+	Params []string `json:"params"`
+	Body   string   `json:"body"`
+}
+
+// MarshalJSON saves the variables and functions of the environment
+func (e *Env) MarshalJSON() ([]byte, error) {
+	saved := savedEnv{Angle: e.Angle, Vars: make(map[string]savedFloat, len(e.vars)), Funcs: []savedFunc{}}
+	for name, v := range e.vars {
+		saved.Vars[name] = savedFloat(v)
+	}
+	for _, f := range e.Funcs() {
+		saved.Funcs = append(saved.Funcs, savedFunc{f.Name, f.Params, f.Body.String()})
+	}
+	return json.Marshal(saved)
+}
+
+// UnmarshalJSON restores an environment saved with MarshalJSON
+// Any previous content of the environment is discarded
+func (e *Env) UnmarshalJSON(data []byte) error {
+	var saved savedEnv
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return err
+	}
+
+	restored := NewEnv()
+	restored.Angle = saved.Angle
+	for name, v := range saved.Vars {
+		if err := restored.Set(name, float64(v)); err != nil {
+			return err
+		}
+	}
+	for _, f := range saved.Funcs {
+		if err := restored.Define(f.Name, f.Params, f.Body); err != nil {
+			return fmt.Errorf("function %s: %w", f.Name, err)
+		}
+	}
+
+	*e = *restored
+	return nil
+}
@@ -0,0 +1,211 @@
+package calculator
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestEnvEval(t *testing.T) {
+	tests := []struct {
+		name     string
+		lines    []string
+		expected float64
+	}{
+		{"assignment returns value", []string{"x = 3"}, 3},
+		{"variable reference", []string{"x = 3", "x * 2"}, 6},
+		{"reassignment uses previous value", []string{"x = 3", "x = x + 1", "x"}, 4},
+		{"user-defined function", []string{"x = 3", "f(a, b) = a^2 + b", "f(x, 2)"}, 11},
+		{"function without parameters", []string{"k() = 42", "k() / 2"}, 21},
+		{"function calling function", []string{"sq(a) = a * a", "g(a) = sq(a) + 1", "g(3)"}, 10},
+		{"parameter shadows variable", []string{"a = 100", "f(a) = a + 1", "f(1)"}, 2},
+		{"body reads globals at call time", []string{"f(a) = a + y", "y = 10", "f(1)"}, 11},
+		{"constants", []string{"2 * pi"}, 2 * math.Pi},
+		{"sqrt", []string{"sqrt(16)"}, 4},
+		{"trigonometry", []string{"sin(0) + cos(0)"}, 1},
+		{"log and exp", []string{"log(exp(2))"}, 2},
+		{"abs", []string{"abs(-7)"}, 7},
+		{"variadic min and max", []string{"min(3, 1, 2) + max(3, 1, 2)"}, 4},
+		{"pow uses Power", []string{"pow(2, 10)"}, 1024},
+		{"unicode names", []string{"π2 = 2", "π2 * 3"}, 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := NewEnv()
+			var result float64
+			for _, line := range tt.lines {
+				var err error
+				result, err = env.Eval(line)
+				if err != nil {
+					t.Fatalf("Eval(%q) unexpected error: %v", line, err)
+				}
+			}
+			if math.Abs(result-tt.expected) > 1e-10 {
+				t.Errorf("Eval(%q) = %f; want %f", tt.lines[len(tt.lines)-1], result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEnvUndefined(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected UndefinedError
+	}{
+		{"variable", "1 + y", UndefinedError{Kind: "variable", Name: "y", Col: 5}},
+		{"function", "2 * g(1)", UndefinedError{Kind: "function", Name: "g", Col: 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewEnv().Eval(tt.input)
+			var undefined *UndefinedError
+			if !errors.As(err, &undefined) {
+				t.Fatalf("Eval(%q) error = %v; want *UndefinedError", tt.input, err)
+			}
+			if *undefined != tt.expected {
+				t.Errorf("Eval(%q) error = %+v; want %+v", tt.input, *undefined, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEnvRecursion(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		chain []string
+	}{
+		{"direct", []string{"f(x) = f(x - 1)"}, []string{"f", "f"}},
+		{"mutual", []string{"f(x) = g(x) + 1", "g(x) = f(x) * 2"}, []string{"g", "f", "g"}},
+		{"through arguments", []string{"f(x) = x", "h(x) = f(h(x))"}, []string{"h", "h"}},
+		{"redefinition", []string{"a(x) = x", "b(x) = a(x)", "a(x) = b(x)"}, []string{"a", "b", "a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := NewEnv()
+			var err error
+			for _, line := range tt.lines {
+				if _, err = env.Eval(line); err != nil {
+					break
+				}
+			}
+			var recursion *RecursionError
+			if !errors.As(err, &recursion) {
+				t.Fatalf("error = %v; want *RecursionError", err)
+			}
+			if !reflect.DeepEqual(recursion.Chain, tt.chain) {
+				t.Errorf("Chain = %v; want %v", recursion.Chain, tt.chain)
+			}
+		})
+	}
+}
+
+func TestEnvErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"assign to constant", "pi = 3"},
+		{"redefine built-in", "sqrt(x) = x"},
+		{"duplicate parameter", "f(a, a) = a"},
+		{"wrong arity", "sqrt(1, 2)"},
+		{"variadic without arguments", "max()"},
+		{"division by zero in assignment", "x = 1 / 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := NewEnv()
+			if _, err := env.Eval(tt.input); err == nil {
+				t.Errorf("Eval(%q) expected error", tt.input)
+			}
+			if len(env.Vars()) != 0 || len(env.Funcs()) != 0 {
+				t.Errorf("Eval(%q) modified the environment on error", tt.input)
+			}
+		})
+	}
+}
+
+func TestEnvAngleUnit(t *testing.T) {
+	env := NewEnv()
+	env.Angle = Degrees
+	result, err := env.Eval("sin(30) + cos(60)")
+	if err != nil {
+		t.Fatalf("Eval unexpected error: %v", err)
+	}
+	if math.Abs(result-1) > 1e-10 {
+		t.Errorf("sin(30) + cos(60) in degrees = %f; want 1", result)
+	}
+}
+
+func TestEnvJSONRoundTrip(t *testing.T) {
+	env := NewEnv()
+	env.Angle = Degrees
+	for _, line := range []string{"x = 3", "rate = 0.25", "f(a, b) = a^2 + b", "g(a) = -f(a, x) / (1 - rate)"} {
+		if _, err := env.Eval(line); err != nil {
+			t.Fatalf("Eval(%q) unexpected error: %v", line, err)
+		}
+	}
+
+	data, err := json.Marshal(env)
+	if err != nil {
+		t.Fatalf("Marshal unexpected error: %v", err)
+	}
+
+	restored := NewEnv()
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("Unmarshal unexpected error: %v", err)
+	}
+	if restored.Angle != Degrees {
+		t.Errorf("restored Angle = %v; want %v", restored.Angle, Degrees)
+	}
+	if !reflect.DeepEqual(restored.Vars(), env.Vars()) {
+		t.Errorf("restored Vars() = %v; want %v", restored.Vars(), env.Vars())
+	}
+
+	want, _ := env.Eval("g(2)")
+	got, err := restored.Eval("g(2)")
+	if err != nil || got != want {
+		t.Errorf("restored g(2) = %f, %v; want %f", got, err, want)
+	}
+}
+
+func TestEnvJSONNonFinite(t *testing.T) {
+	env := NewEnv()
+	for _, line := range []string{"inf = log(0)", "nan = sqrt(-1)", "x = 2"} {
+		if _, err := env.Eval(line); err != nil {
+			t.Fatalf("Eval(%q) unexpected error: %v", line, err)
+		}
+	}
+
+	data, err := json.Marshal(env)
+	if err != nil {
+		t.Fatalf("Marshal unexpected error: %v", err)
+	}
+	restored := NewEnv()
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("Unmarshal(%s) unexpected error: %v", data, err)
+	}
+
+	vars := restored.Vars()
+	if !math.IsInf(vars["inf"], -1) || !math.IsNaN(vars["nan"]) || vars["x"] != 2 {
+		t.Errorf("restored Vars() = %v; want inf = -Inf, nan = NaN, x = 2", vars)
+	}
+	if err := json.Unmarshal([]byte(`{"vars":{"x":"big"},"funcs":[]}`), NewEnv()); err == nil {
+		t.Error(`Unmarshal of "big" succeeded; want an error`)
+	}
+}
+
+func TestEnvUnmarshalRejectsRecursion(t *testing.T) {
+	data := `{"vars":{},"funcs":[{"name":"f","params":["x"],"body":"g(x)"},{"name":"g","params":["x"],"body":"f(x)"}]}`
+	var recursion *RecursionError
+	if err := json.Unmarshal([]byte(data), NewEnv()); !errors.As(err, &recursion) {
+		t.Errorf("Unmarshal error = %v; want *RecursionError", err)
+	}
+}
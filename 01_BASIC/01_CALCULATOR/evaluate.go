@@ -0,0 +1,137 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+)
+
+// Evaluate parses and evaluates an infix expression such as "3 + 4 * (2 - 1)^2"
+// Operators are applied through Add, Subtract, Multiply, Divide and Power,
+// so dividing by zero returns ErrDivisionByZero
+// Built-in functions and constants are available; use an Env for variables
+func Evaluate(expr string) (float64, error) {
+	n, err := Parse(expr)
+	if err != nil {
+		return 0, err
+	}
+	return evaluator{}.eval(n)
+}
+
+// evaluator walks an expression tree computing its value
+// env is nil when evaluating without an Env
+type evaluator struct {
+	env    *Env
+	locals map[string]float64
+}
+
+func (e evaluator) eval(n Node) (float64, error) {
+	switch n := n.(type) {
+	case *Number:
+		return n.Value, nil
+	case *Imaginary:
+		return 0, fmt.Errorf("imaginary literal %s at column %d requires EvaluateComplex", n, n.Pos())
+	case *Ident:
+		return e.lookup(n)
+	case *Call:
+		return e.call(n)
+	case *Unary:
+		x, err := e.eval(n.X)
+		if err != nil {
+			return 0, err
+		}
+		if n.Op == '-' {
+			return -x, nil
+		}
+		return x, nil
+	case *Binary:
+		x, err := e.eval(n.X)
+		if err != nil {
+			return 0, err
+		}
+		y, err := e.eval(n.Y)
+		if err != nil {
+			return 0, err
+		}
+		return applyBinary(n.Op, x, y)
+	}
+	return 0, fmt.Errorf("unsupported node %T", n)
+}
+
+// lookup resolves a name against parameters, variables and constants, in that order
+func (e evaluator) lookup(n *Ident) (float64, error) {
+	if v, ok := e.locals[n.Name]; ok {
+		return v, nil
+	}
+	if e.env != nil {
+		if v, ok := e.env.vars[n.Name]; ok {
+			return v, nil
+		}
+	}
+	if v, ok := constants[n.Name]; ok {
+		return v, nil
+	}
+	return 0, &UndefinedError{Kind: "variable", Name: n.Name, Col: n.Pos()}
+}
+
+// call evaluates a call to a user-defined or built-in function
+func (e evaluator) call(n *Call) (float64, error) {
+	args := make([]float64, len(n.Args))
+	for i, arg := range n.Args {
+		v, err := e.eval(arg)
+		if err != nil {
+			return 0, err
+		}
+		args[i] = v
+	}
+
+	if e.env != nil {
+		if f, ok := e.env.funcs[n.Name]; ok {
+			if len(args) != len(f.Params) {
+				return 0, arityError(n, fmt.Sprint(len(f.Params)))
+			}
+			locals := make(map[string]float64, len(args))
+			for i, p := range f.Params {
+				locals[p] = args[i]
+			}
+			return evaluator{env: e.env, locals: locals}.eval(f.Body)
+		}
+	}
+
+	b, ok := builtins[n.Name]
+	if !ok {
+		return 0, &UndefinedError{Kind: "function", Name: n.Name, Col: n.Pos()}
+	}
+	if b.maxArgs < 0 && len(args) < b.minArgs {
+		return 0, arityError(n, fmt.Sprintf("at least %d", b.minArgs))
+	}
+	if b.maxArgs >= 0 && (len(args) < b.minArgs || len(args) > b.maxArgs) {
+		return 0, arityError(n, fmt.Sprint(b.minArgs))
+	}
+	if b.angular && e.env != nil && e.env.Angle == Degrees {
+		for i := range args {
+			args[i] = args[i] * math.Pi / 180
+		}
+	}
+	return b.fn(args), nil
+}
+
+func arityError(n *Call, want string) error {
+	return fmt.Errorf("%s expects %s arguments, got %d at column %d", n.Name, want, len(n.Args), n.Pos())
+}
+
+// applyBinary applies a binary operator to two operands
+func applyBinary(op rune, x, y float64) (float64, error) {
+	switch op {
+	case '+':
+		return Add(x, y), nil
+	case '-':
+		return Subtract(x, y), nil
+	case '*':
+		return Multiply(x, y), nil
+	case '/':
+		return Divide(x, y)
+	case '^':
+		return Power(x, y), nil
+	}
+	return 0, fmt.Errorf("unknown operator '%c'", op)
+}
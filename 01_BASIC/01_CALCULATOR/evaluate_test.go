@@ -0,0 +1,113 @@
+package calculator
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		expected float64
+	}{
+		{"single number", "42", 42},
+		{"decimal", "2.5", 2.5},
+		{"exponent notation", "1.5e3", 1500},
+		{"addition", "1 + 2", 3},
+		{"precedence", "2 + 3 * 4", 14},
+		{"left associative subtraction", "10 - 4 - 3", 3},
+		{"left associative division", "100 / 10 / 5", 2},
+		{"parentheses", "(2 + 3) * 4", 20},
+		{"power", "2^10", 1024},
+		{"right associative power", "2^3^2", 512},
+		{"unary minus", "-5 + 2", -3},
+		{"unary minus binds looser than power", "-2^2", -4},
+		{"negative exponent", "2^-2", 0.25},
+		{"double negation", "--3", 3},
+		{"nested parentheses", "((1 + 2) * (3 + 4))", 21},
+		{"example from request", "3 + 4 * (2 - 1)^2", 7},
+		{"no spaces", "3+4*2/(1-5)^2", 3.5},
+		{"built-in function", "sqrt(16) + max(1, 2)", 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Evaluate(tt.expr)
+			if err != nil {
+				t.Fatalf("Evaluate(%q) unexpected error: %v", tt.expr, err)
+			}
+			if math.Abs(result-tt.expected) > 1e-10 {
+				t.Errorf("Evaluate(%q) = %f; want %f", tt.expr, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		msg  string
+	}{
+		{"empty", "", "unexpected end of expression at column 1"},
+		{"extra closing paren", "(1 + 2))", "unexpected ')' at column 8"},
+		{"missing closing paren", "(1 + 2", "expected ')', found end of expression at column 7"},
+		{"dangling operator", "1 +", "unexpected end of expression at column 4"},
+		{"double operator", "1 * * 2", "unexpected '*' at column 5"},
+		{"unknown character", "1 $ 2", "unexpected character '$' at column 3"},
+		{"two numbers", "1 2", "unexpected '2' at column 3"},
+		{"empty parentheses", "()", "unexpected ')' at column 2"},
+		{"juxtaposed name", "2e", "unexpected 'e' at column 2"},
+		{"juxtaposed parentheses", "6/2(1+2)", "unexpected '(' at column 4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Evaluate(tt.expr)
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Evaluate(%q) error = %v; want *SyntaxError", tt.expr, err)
+			}
+			if err.Error() != tt.msg {
+				t.Errorf("Evaluate(%q) error = %q; want %q", tt.expr, err.Error(), tt.msg)
+			}
+		})
+	}
+}
+
+func TestEvaluateDivisionByZero(t *testing.T) {
+	_, err := Evaluate("1 / (2 - 2)")
+	_, divideErr := Divide(1, 0)
+	if !errors.Is(err, ErrDivisionByZero) || err.Error() != divideErr.Error() {
+		t.Errorf("Evaluate division by zero error = %v; want %v", err, divideErr)
+	}
+}
+
+func TestParseString(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected string
+	}{
+		{"1+2*3", "1 + 2 * 3"},
+		{"(1+2)*3", "(1 + 2) * 3"},
+		{"1-(2-3)", "1 - (2 - 3)"},
+		{"(2^3)^2", "(2^3)^2"},
+		{"2^3^2", "2^3^2"},
+		{"-2^2", "-2^2"},
+		{"(-2)^2", "(-2)^2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			n, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error: %v", tt.expr, err)
+			}
+			if n.String() != tt.expected {
+				t.Errorf("Parse(%q).String() = %q; want %q", tt.expr, n.String(), tt.expected)
+			}
+		})
+	}
+}
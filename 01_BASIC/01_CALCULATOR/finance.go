@@ -0,0 +1,276 @@
+package calculator
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Financial functions follow the spreadsheet sign convention: money paid out is negative
+// and money received is positive, so PMT for a positive loan amount is negative
+
+// PaymentTiming selects whether payments are due at the end or the beginning of each period
+type PaymentTiming int
+
+const (
+	// PayAtEnd makes payments at the end of each period (an ordinary annuity)
+	PayAtEnd PaymentTiming = iota
+	// PayAtBeginning makes payments at the beginning of each period (an annuity due)
+	PayAtBeginning
+)
+
+// guardDigits are the extra decimal places kept in intermediate results before rounding to the requested precision
+const guardDigits = 20
+
+// FV returns the future value of an investment with a constant rate per period and constant payments
+// pv is the present value; the result is rounded to p
+func FV(rate Decimal, nper int, pmt, pv Decimal, when PaymentTiming, p Precision) (Decimal, error) {
+	g, annuity, err := annuityFactors(rate, nper, when, p)
+	if err != nil {
+		return Decimal{}, err
+	}
+	// fv = -(pv × (1+r)^n + pmt × annuity)
+	fv := BigAdd(BigMultiply(pv, g), BigMultiply(pmt, annuity))
+	return fv.Neg().Round(p), nil
+}
+
+// PV returns the present value of a series of constant payments and a final future value fv
+// The result is rounded to p
+func PV(rate Decimal, nper int, pmt, fv Decimal, when PaymentTiming, p Precision) (Decimal, error) {
+	g, annuity, err := annuityFactors(rate, nper, when, p)
+	if err != nil {
+		return Decimal{}, err
+	}
+	// pv = -(fv + pmt × annuity) / (1+r)^n
+	pv, err := BigDivide(BigAdd(fv, BigMultiply(pmt, annuity)), g, p)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return pv.Neg(), nil
+}
+
+// PMT returns the constant payment per period that turns the present value pv into the future value fv,
+// e.g. the monthly payment of a loan; the result is rounded to p
+// If nper is 0, returns ErrDivisionByZero
+func PMT(rate Decimal, nper int, pv, fv Decimal, when PaymentTiming, p Precision) (Decimal, error) {
+	g, annuity, err := annuityFactors(rate, nper, when, p)
+	if err != nil {
+		return Decimal{}, err
+	}
+	// pmt = -(fv + pv × (1+r)^n) / annuity
+	pmt, err := BigDivide(BigAdd(fv, BigMultiply(pv, g)), annuity, p)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return pmt.Neg(), nil
+}
+
+// annuityFactors returns the growth factor (1+r)^n and the value after n periods of payments of 1,
+// ((1+r)^n - 1) / r, adjusted for payments at the beginning of each period
+func annuityFactors(rate Decimal, nper int, when PaymentTiming, p Precision) (growth, annuity Decimal, err error) {
+	if nper < 0 {
+		return Decimal{}, Decimal{}, fmt.Errorf("number of periods must not be negative, got %d", nper)
+	}
+	one := NewDecimal(1, 0)
+	if rate.Sign() == 0 {
+		return one, NewDecimal(int64(nper), 0), nil
+	}
+
+	work := Precision{Scale: p.Scale + guardDigits}
+	growth, err = BigPower(BigAdd(one, rate), nper, work)
+	if err != nil {
+		return Decimal{}, Decimal{}, err
+	}
+	annuity, err = BigDivide(BigSubtract(growth, one), rate, work)
+	if err != nil {
+		return Decimal{}, Decimal{}, err
+	}
+	if when == PayAtBeginning {
+		annuity = BigMultiply(annuity, BigAdd(one, rate)).Round(work)
+	}
+	return growth, annuity, nil
+}
+
+// NPV returns the net present value of cash flows discounted at rate per period
+// As in spreadsheets, the first cash flow is discounted one full period; add an
+// initial investment at time 0 separately
+func NPV(rate Decimal, cashFlows []Decimal, p Precision) (Decimal, error) {
+	work := Precision{Scale: p.Scale + guardDigits}
+	factor := BigAdd(NewDecimal(1, 0), rate)
+	discount := NewDecimal(1, 0)
+	var total Decimal
+	for _, cf := range cashFlows {
+		var err error
+		if discount, err = BigDivide(discount, factor, work); err != nil {
+			return Decimal{}, err
+		}
+		total = BigAdd(total, BigMultiply(cf, discount))
+	}
+	return total.Round(p), nil
+}
+
+// CashFlow is an amount paid or received on a date
+type CashFlow struct {
+	Date   time.Time
+	Amount Decimal
+}
+
+// IRR returns the internal rate of return per period of cash flows at regular intervals,
+// the rate at which their net present value is 0; the first cash flow is at time 0
+// Cash flows must contain at least one positive and one negative amount
+// Defaults: Tolerance 1e-10, 100 iterations
+func IRR(cashFlows []Decimal, opts Options) (Result, error) {
+	times := make([]float64, len(cashFlows))
+	for i := range times {
+		times[i] = float64(i)
+	}
+	return internalRate("irr", times, cashFlows, opts)
+}
+
+// XIRR returns the annual internal rate of return of cash flows on arbitrary dates
+// Each amount is discounted by the number of days since the first cash flow over 365
+// Cash flows must contain at least one positive and one negative amount
+// Defaults: Tolerance 1e-10, 100 iterations
+func XIRR(cashFlows []CashFlow, opts Options) (Result, error) {
+	if len(cashFlows) == 0 {
+		return Result{}, errors.New("xirr: no cash flows")
+	}
+	times := make([]float64, len(cashFlows))
+	amounts := make([]Decimal, len(cashFlows))
+	for i, cf := range cashFlows {
+		days := cf.Date.Sub(cashFlows[0].Date).Hours() / 24
+		if days < 0 {
+			return Result{}, fmt.Errorf("xirr: cash flow %d is dated before the first one", i+1)
+		}
+		times[i], amounts[i] = days/365, cf.Amount
+	}
+	return internalRate("xirr", times, amounts, opts)
+}
+
+// internalRate solves Σ amount / (1+r)^t = 0 with Newton's method from 10%,
+// falling back to Brent's method when Newton diverges
+func internalRate(method string, times []float64, amounts []Decimal, opts Options) (Result, error) {
+	values := make([]float64, len(amounts))
+	var positive, negative bool
+	for i, a := range amounts {
+		values[i] = a.Float64()
+		positive = positive || a.Sign() > 0
+		negative = negative || a.Sign() < 0
+	}
+	if !positive || !negative {
+		return Result{}, fmt.Errorf("%s: cash flows need at least one positive and one negative amount", method)
+	}
+
+	npv := func(r float64) float64 {
+		total := 0.0
+		for i, v := range values {
+			total += v / math.Pow(1+r, times[i])
+		}
+		return total
+	}
+	dnpv := func(r float64) float64 {
+		total := 0.0
+		for i, v := range values {
+			total -= times[i] * v / math.Pow(1+r, times[i]+1)
+		}
+		return total
+	}
+
+	if r, err := Newton(npv, dnpv, 0.1, opts); err == nil && r.Value > -1 {
+		return r, nil
+	}
+
+	// Look for a sign change between -99.99% and 1000000%
+	// Near -100% the discount factors overflow over long horizons, so points where
+	// the net present value is not finite are skipped rather than compared
+	lo, loValue := math.NaN(), math.NaN()
+	for _, hi := range []float64{-0.9999, -0.999, -0.99, -0.9, -0.5, 0, 0.1, 0.5, 1, 10, 100, 1e4} {
+		v := npv(hi)
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		if !math.IsNaN(loValue) && math.Signbit(loValue) != math.Signbit(v) {
+			return Brent(npv, lo, hi, opts)
+		}
+		lo, loValue = hi, v
+	}
+	return Result{}, fmt.Errorf("%s: %w: net present value does not change sign", method, ErrNoConvergence)
+}
+
+// AmortizationRow is one period of an amortization schedule
+type AmortizationRow struct {
+	Period    int
+	Payment   Decimal
+	Principal Decimal
+	Interest  Decimal
+	Balance   Decimal
+}
+
+// Schedule is an amortization schedule with one row per period
+type Schedule []AmortizationRow
+
+// Amortize returns the schedule for repaying principal over nper periods at rate per period
+// with equal payments at the end of each period
+// Every amount is rounded to p (e.g. cents) and the last payment absorbs the rounding,
+// so the principal column adds up exactly to principal and the final balance is 0
+func Amortize(principal, rate Decimal, nper int, p Precision) (Schedule, error) {
+	if nper <= 0 {
+		return nil, fmt.Errorf("number of periods must be positive, got %d", nper)
+	}
+	payment, err := PMT(rate, nper, principal, Decimal{}, PayAtEnd, p)
+	if err != nil {
+		return nil, err
+	}
+	payment = payment.Neg()
+
+	schedule := make(Schedule, nper)
+	balance := principal.Round(p)
+	for i := range schedule {
+		interest := BigMultiply(balance, rate).Round(p)
+		row := AmortizationRow{Period: i + 1, Payment: payment, Interest: interest}
+		if i == nper-1 {
+			row.Payment = BigAdd(balance, interest)
+		}
+		row.Principal = BigSubtract(row.Payment, interest)
+		balance = BigSubtract(balance, row.Principal)
+		row.Balance = balance
+		schedule[i] = row
+	}
+	return schedule, nil
+}
+
+// TotalInterest returns the interest paid over the whole schedule
+func (s Schedule) TotalInterest() Decimal {
+	var total Decimal
+	for _, row := range s {
+		total = BigAdd(total, row.Interest)
+	}
+	return total
+}
+
+// WriteCSV writes the schedule as CSV with a header row:
+// period,payment,principal,interest,balance
+func (s Schedule) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"period", "payment", "principal", "interest", "balance"}); err != nil {
+		return err
+	}
+	for _, row := range s {
+		record := []string{
+			strconv.Itoa(row.Period),
+			row.Payment.String(),
+			row.Principal.String(),
+			row.Interest.String(),
+			row.Balance.String(),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,183 @@
+package calculator
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func monthly(t *testing.T, annual string) Decimal {
+	t.Helper()
+	r, _ := BigDivide(dec(t, annual), NewDecimal(12, 0), Precision{Scale: 30})
+	return r
+}
+
+func TestTimeValueOfMoney(t *testing.T) {
+	cents := Precision{Scale: 2}
+	tests := []struct {
+		name     string
+		fn       func() (Decimal, error)
+		expected string
+	}{
+		{"pmt mortgage", func() (Decimal, error) {
+			return PMT(monthly(t, "0.05"), 360, dec(t, "200000"), Decimal{}, PayAtEnd, cents)
+		}, "-1073.64"},
+		{"pmt zero rate", func() (Decimal, error) { return PMT(Decimal{}, 12, dec(t, "1200"), Decimal{}, PayAtEnd, cents) }, "-100.00"},
+		{"pmt savings goal", func() (Decimal, error) { return PMT(dec(t, "0.05"), 10, Decimal{}, dec(t, "10000"), PayAtEnd, cents) }, "-795.05"},
+		{"fv", func() (Decimal, error) {
+			return FV(dec(t, "0.05"), 10, dec(t, "-100"), dec(t, "-1000"), PayAtEnd, cents)
+		}, "2886.68"},
+		{"fv zero rate", func() (Decimal, error) { return FV(Decimal{}, 10, dec(t, "-100"), dec(t, "-1000"), PayAtEnd, cents) }, "2000.00"},
+		{"pv", func() (Decimal, error) { return PV(monthly(t, "0.08"), 240, dec(t, "500"), Decimal{}, PayAtEnd, cents) }, "-59777.15"},
+		{"pv annuity due", func() (Decimal, error) {
+			return PV(monthly(t, "0.08"), 240, dec(t, "500"), Decimal{}, PayAtBeginning, cents)
+		}, "-60175.66"},
+		{"npv", func() (Decimal, error) {
+			return NPV(dec(t, "0.1"), []Decimal{dec(t, "-10000"), dec(t, "3000"), dec(t, "4200"), dec(t, "6800")}, cents)
+		}, "1188.44"},
+		{"npv no cash flows", func() (Decimal, error) { return NPV(dec(t, "0.1"), nil, cents) }, "0.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.String() != tt.expected {
+				t.Errorf("got %s; want %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTimeValueOfMoneyErrors(t *testing.T) {
+	cents := Precision{Scale: 2}
+	if _, err := PMT(dec(t, "0.05"), 0, dec(t, "1000"), Decimal{}, PayAtEnd, cents); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("PMT with no periods error = %v; want ErrDivisionByZero", err)
+	}
+	if _, err := FV(dec(t, "0.05"), -1, dec(t, "-100"), Decimal{}, PayAtEnd, cents); err == nil {
+		t.Errorf("FV with negative periods expected error")
+	}
+	if _, err := NPV(dec(t, "-1"), []Decimal{dec(t, "100")}, cents); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("NPV at -100%% error = %v; want ErrDivisionByZero", err)
+	}
+}
+
+func TestIRR(t *testing.T) {
+	tests := []struct {
+		name      string
+		cashFlows []string
+		expected  float64
+	}{
+		{"investment", []string{"-70000", "12000", "15000", "18000", "21000", "26000"}, 0.08663094803653162},
+		{"loss", []string{"-70000", "12000", "15000", "18000", "21000"}, -0.021244848273410923},
+		{"single period", []string{"-100", "110"}, 0.1},
+		{"high return", []string{"-1", "0", "0", "1000"}, 9},
+		{"long horizon near -100%", slices.Concat([]string{"-1"}, slices.Repeat([]string{"0"}, 118), []string{"-20", "1"}), -0.95},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flows := make([]Decimal, len(tt.cashFlows))
+			for i, s := range tt.cashFlows {
+				flows[i] = dec(t, s)
+			}
+			r, err := IRR(flows, Options{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if math.Abs(r.Value-tt.expected) > 1e-9 || !r.Converged {
+				t.Errorf("IRR(%v) = %v; want %v", tt.cashFlows, r.Value, tt.expected)
+			}
+		})
+	}
+
+	if _, err := IRR([]Decimal{dec(t, "100"), dec(t, "200")}, Options{}); err == nil {
+		t.Errorf("IRR without a negative cash flow expected error")
+	}
+}
+
+func TestXIRR(t *testing.T) {
+	date := func(s string) time.Time {
+		d, _ := time.Parse(time.DateOnly, s)
+		return d
+	}
+	flows := []CashFlow{
+		{date("2008-01-01"), dec(t, "-10000")},
+		{date("2008-03-01"), dec(t, "2750")},
+		{date("2008-10-30"), dec(t, "4250")},
+		{date("2009-02-15"), dec(t, "3250")},
+		{date("2009-04-01"), dec(t, "2750")},
+	}
+	r, err := XIRR(flows, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(r.Value-0.373362535) > 1e-8 {
+		t.Errorf("XIRR = %v; want 0.373362535", r.Value)
+	}
+
+	if _, err := XIRR(nil, Options{}); err == nil {
+		t.Errorf("XIRR without cash flows expected error")
+	}
+	flows[0], flows[1] = flows[1], flows[0]
+	if _, err := XIRR(flows, Options{}); err == nil {
+		t.Errorf("XIRR with an earlier date after the first expected error")
+	}
+}
+
+func TestAmortize(t *testing.T) {
+	cents := Precision{Scale: 2}
+	schedule, err := Amortize(dec(t, "10000"), monthly(t, "0.06"), 12, cents)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(schedule) != 12 {
+		t.Fatalf("len(schedule) = %d; want 12", len(schedule))
+	}
+
+	first, last := schedule[0], schedule[11]
+	if first.Payment.String() != "860.66" || first.Interest.String() != "50.00" || first.Principal.String() != "810.66" || first.Balance.String() != "9189.34" {
+		t.Errorf("first row = %+v", first)
+	}
+	if last.Balance.Sign() != 0 || last.Payment.String() != "860.70" {
+		t.Errorf("last row = payment %s, balance %s; want 860.70, 0", last.Payment, last.Balance)
+	}
+
+	var principal Decimal
+	for _, row := range schedule {
+		principal = BigAdd(principal, row.Principal)
+		if sum := BigAdd(row.Principal, row.Interest); sum.Cmp(row.Payment) != 0 {
+			t.Errorf("period %d: principal + interest = %s; want payment %s", row.Period, sum, row.Payment)
+		}
+	}
+	if principal.String() != "10000.00" {
+		t.Errorf("total principal = %s; want 10000.00", principal)
+	}
+	if got := schedule.TotalInterest().String(); got != "327.96" {
+		t.Errorf("TotalInterest() = %s; want 327.96", got)
+	}
+
+	if _, err := Amortize(dec(t, "10000"), monthly(t, "0.06"), 0, cents); err == nil {
+		t.Errorf("Amortize with no periods expected error")
+	}
+}
+
+func TestScheduleWriteCSV(t *testing.T) {
+	schedule, _ := Amortize(dec(t, "300"), Decimal{}, 3, Precision{Scale: 2})
+	var b strings.Builder
+	if err := schedule.WriteCSV(&b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "period,payment,principal,interest,balance\n" +
+		"1,100.00,100.00,0.00,200.00\n" +
+		"2,100.00,100.00,0.00,100.00\n" +
+		"3,100.00,100.00,0.00,0.00\n"
+	if b.String() != expected {
+		t.Errorf("WriteCSV() = %q; want %q", b.String(), expected)
+	}
+}
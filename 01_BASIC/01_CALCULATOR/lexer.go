@@ -0,0 +1,139 @@
+package calculator
+
+import (
+	"fmt"
+	"unicode"
+)
+
+// tokenKind identifies the lexical class of a token
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokImaginary
+	tokIdent
+	tokOperator
+	tokLParen
+	tokRParen
+	tokComma
+	tokAssign
+)
+
+// token is a lexical unit of an expression
+// col is the 1-based column where the token starts
+type token struct {
+	kind tokenKind
+	text string
+	col  int
+}
+
+// describe returns the token as it should appear in error messages
+func (t token) describe() string {
+	if t.kind == tokEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("'%s'", t.text)
+}
+
+// SyntaxError reports a malformed expression
+// Col is the 1-based column where the problem was detected
+type SyntaxError struct {
+	Col int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at column %d", e.Msg, e.Col)
+}
+
+// tokenize splits an expression into tokens, ending with a tokEOF token
+func tokenize(expr string) ([]token, error) {
+	src := []rune(expr)
+	var tokens []token
+
+	for i := 0; i < len(src); {
+		r := src[i]
+		col := i + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case isDigit(r) || (r == '.' && i+1 < len(src) && isDigit(src[i+1])):
+			end := scanNumber(src, i)
+			// A trailing i makes an imaginary literal such as 4i, unless it starts a name
+			if end < len(src) && src[end] == 'i' && (end+1 == len(src) || !isIdentPart(src[end+1])) {
+				tokens = append(tokens, token{tokImaginary, string(src[i : end+1]), col})
+				i = end + 1
+				continue
+			}
+			tokens = append(tokens, token{tokNumber, string(src[i:end]), col})
+			i = end
+		case isIdentStart(r):
+			end := i + 1
+			for end < len(src) && isIdentPart(src[end]) {
+				end++
+			}
+			tokens = append(tokens, token{tokIdent, string(src[i:end]), col})
+			i = end
+		case r == '+' || r == '-' || r == '*' || r == '/' || r == '^':
+			tokens = append(tokens, token{tokOperator, string(r), col})
+			i++
+		case r == '(':
+			tokens = append(tokens, token{tokLParen, "(", col})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{tokRParen, ")", col})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{tokComma, ",", col})
+			i++
+		case r == '=':
+			tokens = append(tokens, token{tokAssign, "=", col})
+			i++
+		default:
+			return nil, &SyntaxError{Col: col, Msg: fmt.Sprintf("unexpected character '%c'", r)}
+		}
+	}
+
+	return append(tokens, token{tokEOF, "", len(src) + 1}), nil
+}
+
+// scanNumber returns the index just past the number literal starting at i
+// Accepts an integer part, an optional fraction and an optional exponent
+func scanNumber(src []rune, i int) int {
+	for i < len(src) && isDigit(src[i]) {
+		i++
+	}
+	if i < len(src) && src[i] == '.' {
+		i++
+		for i < len(src) && isDigit(src[i]) {
+			i++
+		}
+	}
+	if i < len(src) && (src[i] == 'e' || src[i] == 'E') {
+		j := i + 1
+		if j < len(src) && (src[j] == '+' || src[j] == '-') {
+			j++
+		}
+		if j < len(src) && isDigit(src[j]) {
+			for j < len(src) && isDigit(src[j]) {
+				j++
+			}
+			i = j
+		}
+	}
+	return i
+}
+
+func isIdentStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func isIdentPart(r rune) bool {
+	return isIdentStart(r) || unicode.IsDigit(r)
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
@@ -0,0 +1,530 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ErrSingularMatrix is returned when inverting or solving with a matrix that has no inverse
+var ErrSingularMatrix = errors.New("singular matrix")
+
+// ShapeError reports an operation between matrices or vectors of incompatible sizes
+type ShapeError struct {
+	Op          string
+	Left, Right string
+}
+
+func (e *ShapeError) Error() string {
+	if e.Right == "" {
+		return fmt.Sprintf("cannot %s a %s matrix", e.Op, e.Left)
+	}
+	return fmt.Sprintf("cannot %s %s and %s", e.Op, e.Left, e.Right)
+}
+
+// Vector is a column vector
+type Vector []float64
+
+// Dot returns the dot product of v and w
+// Returns a *ShapeError if their lengths differ
+func (v Vector) Dot(w Vector) (float64, error) {
+	if len(v) != len(w) {
+		return 0, &ShapeError{Op: "multiply", Left: v.shape(), Right: w.shape()}
+	}
+	total := 0.0
+	for i := range v {
+		total += v[i] * w[i]
+	}
+	return total, nil
+}
+
+// Norm returns the Euclidean length of v
+func (v Vector) Norm() float64 {
+	total := 0.0
+	for _, x := range v {
+		total = math.Hypot(total, x)
+	}
+	return total
+}
+
+func (v Vector) shape() string {
+	return fmt.Sprintf("vector of length %d", len(v))
+}
+
+// String formats v as a literal such as [1,2,3]
+func (v Vector) String() string {
+	return "[" + formatEntries(v) + "]"
+}
+
+// Matrix is an immutable rows × cols matrix of float64 values
+// The zero value is the empty 0 × 0 matrix
+type Matrix struct {
+	rows, cols int
+	data       []float64
+}
+
+// NewMatrix returns a matrix from its rows, which must all have the same length
+func NewMatrix(rows [][]float64) (Matrix, error) {
+	if len(rows) == 0 {
+		return Matrix{}, nil
+	}
+	m := Matrix{rows: len(rows), cols: len(rows[0]), data: make([]float64, 0, len(rows)*len(rows[0]))}
+	for i, row := range rows {
+		if len(row) != m.cols {
+			return Matrix{}, fmt.Errorf("row %d has %d entries; want %d", i+1, len(row), m.cols)
+		}
+		m.data = append(m.data, row...)
+	}
+	return m, nil
+}
+
+// Identity returns the n × n identity matrix
+func Identity(n int) Matrix {
+	m := zeros(n, n)
+	for i := range n {
+		m.data[i*n+i] = 1
+	}
+	return m
+}
+
+func zeros(rows, cols int) Matrix {
+	return Matrix{rows: rows, cols: cols, data: make([]float64, rows*cols)}
+}
+
+// Rows returns the number of rows of m
+func (m Matrix) Rows() int {
+	return m.rows
+}
+
+// Cols returns the number of columns of m
+func (m Matrix) Cols() int {
+	return m.cols
+}
+
+// At returns the entry in row i and column j, counting from 0
+func (m Matrix) At(i, j int) float64 {
+	if i < 0 || i >= m.rows || j < 0 || j >= m.cols {
+		panic(fmt.Sprintf("index (%d, %d) out of range for a %s matrix", i, j, m.shape()))
+	}
+	return m.data[i*m.cols+j]
+}
+
+// Row returns a copy of row i
+func (m Matrix) Row(i int) Vector {
+	return append(Vector(nil), m.data[i*m.cols:(i+1)*m.cols]...)
+}
+
+func (m Matrix) shape() string {
+	return fmt.Sprintf("%dx%d", m.rows, m.cols)
+}
+
+// Equal reports whether m and o have the same shape and entries within tolerance
+func (m Matrix) Equal(o Matrix, tolerance float64) bool {
+	if m.rows != o.rows || m.cols != o.cols {
+		return false
+	}
+	for i := range m.data {
+		if math.Abs(m.data[i]-o.data[i]) > tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+// MatrixAdd returns a + b
+// Returns a *ShapeError if the matrices have different sizes
+func MatrixAdd(a, b Matrix) (Matrix, error) {
+	return elementwise("add", a, b, Add)
+}
+
+// MatrixSubtract returns a - b
+// Returns a *ShapeError if the matrices have different sizes
+func MatrixSubtract(a, b Matrix) (Matrix, error) {
+	return elementwise("subtract", a, b, Subtract)
+}
+
+func elementwise(op string, a, b Matrix, fn func(x, y float64) float64) (Matrix, error) {
+	if a.rows != b.rows || a.cols != b.cols {
+		return Matrix{}, &ShapeError{Op: op, Left: a.shape(), Right: b.shape()}
+	}
+	out := zeros(a.rows, a.cols)
+	for i := range out.data {
+		out.data[i] = fn(a.data[i], b.data[i])
+	}
+	return out, nil
+}
+
+// MatrixScale returns every entry of m multiplied by k
+func MatrixScale(m Matrix, k float64) Matrix {
+	out := zeros(m.rows, m.cols)
+	for i, v := range m.data {
+		out.data[i] = Multiply(v, k)
+	}
+	return out
+}
+
+// MatrixMultiply returns the matrix product a × b
+// Returns a *ShapeError if a does not have as many columns as b has rows
+func MatrixMultiply(a, b Matrix) (Matrix, error) {
+	if a.cols != b.rows {
+		return Matrix{}, &ShapeError{Op: "multiply", Left: a.shape(), Right: b.shape()}
+	}
+	out := zeros(a.rows, b.cols)
+	for i := range a.rows {
+		for k := range a.cols {
+			aik := a.data[i*a.cols+k]
+			for j := range b.cols {
+				out.data[i*b.cols+j] += aik * b.data[k*b.cols+j]
+			}
+		}
+	}
+	return out, nil
+}
+
+// MulVec returns the matrix-vector product m × v
+// Returns a *ShapeError if the length of v is not the number of columns of m
+func (m Matrix) MulVec(v Vector) (Vector, error) {
+	if len(v) != m.cols {
+		return nil, &ShapeError{Op: "multiply", Left: m.shape(), Right: v.shape()}
+	}
+	out := make(Vector, m.rows)
+	for i := range m.rows {
+		out[i], _ = m.Row(i).Dot(v)
+	}
+	return out, nil
+}
+
+// Transpose returns m with rows and columns swapped
+func (m Matrix) Transpose() Matrix {
+	out := zeros(m.cols, m.rows)
+	for i := range m.rows {
+		for j := range m.cols {
+			out.data[j*m.rows+i] = m.data[i*m.cols+j]
+		}
+	}
+	return out
+}
+
+// LU is the decomposition P × A = L × U of a square matrix A with partial pivoting,
+// where L is unit lower triangular, U is upper triangular and P permutes rows
+type LU struct {
+	L, U Matrix
+	// Perm maps each row of P × A to its row in A
+	Perm []int
+	// sign is the determinant of P, +1 or -1
+	sign float64
+}
+
+// LU returns the LU decomposition of m
+// Returns a *ShapeError if m is not square and ErrSingularMatrix if a pivot is zero
+// relative to the scale of its row
+func (m Matrix) LU() (LU, error) {
+	if m.rows != m.cols {
+		return LU{}, &ShapeError{Op: "decompose", Left: m.shape()}
+	}
+	n := m.rows
+	u := Matrix{rows: n, cols: n, data: append([]float64(nil), m.data...)}
+	l := Identity(n)
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	sign := 1.0
+	// A pivot is treated as zero when it is within rounding error of the entries of its
+	// own row, so rows of very different magnitudes do not make each other look singular
+	tolerance := make([]float64, n)
+	for i := range n {
+		tolerance[i] = float64(n) * epsilon * maxAbs(m.data[i*n:(i+1)*n])
+	}
+
+	for k := range n {
+		// Partial pivoting: use the largest remaining entry in column k
+		pivot := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(u.data[i*n+k]) > math.Abs(u.data[pivot*n+k]) {
+				pivot = i
+			}
+		}
+		if math.Abs(u.data[pivot*n+k]) <= tolerance[perm[pivot]] {
+			return LU{}, ErrSingularMatrix
+		}
+		if pivot != k {
+			u.swapRows(k, pivot)
+			// Only the multipliers already computed move with the rows of L
+			for j := range k {
+				l.data[k*n+j], l.data[pivot*n+j] = l.data[pivot*n+j], l.data[k*n+j]
+			}
+			perm[k], perm[pivot] = perm[pivot], perm[k]
+			sign = -sign
+		}
+
+		for i := k + 1; i < n; i++ {
+			factor := u.data[i*n+k] / u.data[k*n+k]
+			l.data[i*n+k] = factor
+			for j := k; j < n; j++ {
+				u.data[i*n+j] -= factor * u.data[k*n+j]
+			}
+			u.data[i*n+k] = 0
+		}
+	}
+	return LU{L: l, U: u, Perm: perm, sign: sign}, nil
+}
+
+func (m Matrix) swapRows(a, b int) {
+	for j := range m.cols {
+		m.data[a*m.cols+j], m.data[b*m.cols+j] = m.data[b*m.cols+j], m.data[a*m.cols+j]
+	}
+}
+
+func maxAbs(values []float64) float64 {
+	largest := 0.0
+	for _, v := range values {
+		largest = math.Max(largest, math.Abs(v))
+	}
+	return largest
+}
+
+// Det returns the determinant of the decomposed matrix
+func (d LU) Det() float64 {
+	det := d.sign
+	for i := range d.U.rows {
+		det *= d.U.data[i*d.U.cols+i]
+	}
+	return det
+}
+
+// Solve returns x such that A × x = b for the decomposed matrix A
+// Returns a *ShapeError if the length of b does not match A
+func (d LU) Solve(b Vector) (Vector, error) {
+	n := d.U.rows
+	if len(b) != n {
+		return nil, &ShapeError{Op: "solve", Left: d.U.shape(), Right: b.shape()}
+	}
+
+	// Forward substitution with L y = P b, then back substitution with U x = y
+	x := make(Vector, n)
+	for i := range n {
+		x[i] = b[d.Perm[i]]
+		for j := range i {
+			x[i] -= d.L.data[i*n+j] * x[j]
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := i + 1; j < n; j++ {
+			x[i] -= d.U.data[i*n+j] * x[j]
+		}
+		x[i] /= d.U.data[i*n+i]
+	}
+	return x, nil
+}
+
+// Det returns the determinant of m
+// Returns a *ShapeError if m is not square; a singular matrix has determinant 0
+func (m Matrix) Det() (float64, error) {
+	d, err := m.LU()
+	if errors.Is(err, ErrSingularMatrix) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return d.Det(), nil
+}
+
+// Inverse returns the inverse of m
+// Returns a *ShapeError if m is not square and ErrSingularMatrix if it has no inverse
+func (m Matrix) Inverse() (Matrix, error) {
+	d, err := m.LU()
+	if err != nil {
+		return Matrix{}, err
+	}
+	n := m.rows
+	inv := zeros(n, n)
+	for j := range n {
+		e := make(Vector, n)
+		e[j] = 1
+		col, err := d.Solve(e)
+		if err != nil {
+			return Matrix{}, err
+		}
+		for i, v := range col {
+			inv.data[i*n+j] = v
+		}
+	}
+	return inv, nil
+}
+
+// Solve returns x such that a × x = b
+// Returns a *ShapeError if the sizes do not match and ErrSingularMatrix if a has no inverse
+func Solve(a Matrix, b Vector) (Vector, error) {
+	d, err := a.LU()
+	if err != nil {
+		return nil, err
+	}
+	return d.Solve(b)
+}
+
+// String formats m as a literal such as [[1,2],[3,4]] that ParseMatrix reads back
+func (m Matrix) String() string {
+	rows := make([]string, m.rows)
+	for i := range rows {
+		rows[i] = m.Row(i).String()
+	}
+	return "[" + strings.Join(rows, ",") + "]"
+}
+
+func formatEntries(v []float64) string {
+	entries := make([]string, len(v))
+	for i, x := range v {
+		entries[i] = strconv.FormatFloat(x, 'g', -1, 64)
+	}
+	return strings.Join(entries, ",")
+}
+
+// ParseMatrix parses a matrix literal such as [[1,2],[3,4]]
+// Entries are expressions, so [[cos(pi), 1/2]] is allowed; rows must all have the same length
+func ParseMatrix(s string) (Matrix, error) {
+	p := &matrixParser{src: []rune(s)}
+	rows, err := p.parse()
+	if err != nil {
+		return Matrix{}, err
+	}
+	return NewMatrix(rows)
+}
+
+// ParseVector parses a vector literal such as [1,2,3]
+func ParseVector(s string) (Vector, error) {
+	p := &matrixParser{src: []rune(s)}
+	v, err := p.parseRow()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectEnd(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+type matrixParser struct {
+	src []rune
+	pos int
+}
+
+func (p *matrixParser) parse() ([][]float64, error) {
+	if err := p.expect('['); err != nil {
+		return nil, err
+	}
+	var rows [][]float64
+	if p.peek() == ']' {
+		p.pos++
+		return rows, p.expectEnd()
+	}
+	for {
+		row, err := p.parseRow()
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+		if p.peek() == ']' {
+			p.pos++
+			return rows, p.expectEnd()
+		}
+		if err := p.expect(','); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *matrixParser) parseRow() ([]float64, error) {
+	if err := p.expect('['); err != nil {
+		return nil, err
+	}
+	var row []float64
+	if p.peek() == ']' {
+		p.pos++
+		return row, nil
+	}
+	for {
+		v, err := p.parseEntry(len(row) + 1)
+		if err != nil {
+			return nil, err
+		}
+		row = append(row, v)
+		if p.peek() == ']' {
+			p.pos++
+			return row, nil
+		}
+		if err := p.expect(','); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parseEntry evaluates the expression up to the next ',' or ']' outside parentheses
+func (p *matrixParser) parseEntry(n int) (float64, error) {
+	p.skipSpace()
+	start, depth := p.pos, 0
+	for ; p.pos < len(p.src); p.pos++ {
+		switch r := p.src[p.pos]; {
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case (r == ',' || r == ']') && depth == 0:
+			return p.evaluate(start, n)
+		}
+	}
+	return p.evaluate(start, n)
+}
+
+func (p *matrixParser) evaluate(start, n int) (float64, error) {
+	text := string(p.src[start:p.pos])
+	if strings.TrimSpace(text) == "" {
+		return 0, &SyntaxError{Col: start + 1, Msg: fmt.Sprintf("expected entry, found %s", p.describe())}
+	}
+	v, err := Evaluate(text)
+	if err != nil {
+		return 0, fmt.Errorf("entry %d at column %d: %w", n, start+1, err)
+	}
+	return v, nil
+}
+
+func (p *matrixParser) expect(r rune) error {
+	if p.peek() != r {
+		return &SyntaxError{Col: p.pos + 1, Msg: fmt.Sprintf("expected '%c', found %s", r, p.describe())}
+	}
+	p.pos++
+	return nil
+}
+
+func (p *matrixParser) expectEnd() error {
+	if p.peek() != 0 {
+		return &SyntaxError{Col: p.pos + 1, Msg: fmt.Sprintf("unexpected %s", p.describe())}
+	}
+	return nil
+}
+
+// peek skips spaces and returns the next rune, or 0 at the end of the input
+func (p *matrixParser) peek() rune {
+	p.skipSpace()
+	if p.pos >= len(p.src) {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *matrixParser) skipSpace() {
+	for p.pos < len(p.src) && unicode.IsSpace(p.src[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *matrixParser) describe() string {
+	if p.pos >= len(p.src) {
+		return "end of expression"
+	}
+	return fmt.Sprintf("'%c'", p.src[p.pos])
+}
@@ -0,0 +1,255 @@
+package calculator
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func mat(t *testing.T, s string) Matrix {
+	t.Helper()
+	m, err := ParseMatrix(s)
+	if err != nil {
+		t.Fatalf("ParseMatrix(%q) unexpected error: %v", s, err)
+	}
+	return m
+}
+
+func TestParseMatrix(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		rows     int
+		cols     int
+	}{
+		{"square", "[[1,2],[3,4]]", "[[1,2],[3,4]]", 2, 2},
+		{"spaces", " [ [1, 2, 3] , [4, 5, 6] ] ", "[[1,2,3],[4,5,6]]", 2, 3},
+		{"expressions", "[[cos(pi), 1/2], [-2^2, min(3, 4)]]", "[[-1,0.5],[-4,3]]", 2, 2},
+		{"column", "[[1],[2]]", "[[1],[2]]", 2, 1},
+		{"empty", "[]", "[]", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mat(t, tt.input)
+			if m.String() != tt.expected || m.Rows() != tt.rows || m.Cols() != tt.cols {
+				t.Errorf("ParseMatrix(%q) = %s (%dx%d); want %s", tt.input, m, m.Rows(), m.Cols(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseMatrixErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		col   int
+	}{
+		{"[1,2]", 2},
+		{"[[1,2],[3,4]", 13},
+		{"[[1,2]]]", 8},
+		{"[[1,,2]]", 5},
+		{"[[1 2]]", 0},
+		{"[[1,2],[3]]", 0},
+		{"[[1,x]]", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := ParseMatrix(tt.input)
+			if err == nil {
+				t.Fatalf("ParseMatrix(%q) expected error", tt.input)
+			}
+			var syntaxErr *SyntaxError
+			if tt.col > 0 && (!errors.As(err, &syntaxErr) || syntaxErr.Col != tt.col) {
+				t.Errorf("ParseMatrix(%q) error = %v; want syntax error at column %d", tt.input, err, tt.col)
+			}
+		})
+	}
+
+	var undefined *UndefinedError
+	if _, err := ParseMatrix("[[1,x]]"); !errors.As(err, &undefined) {
+		t.Errorf("ParseMatrix with unknown name error = %v; want *UndefinedError", err)
+	}
+}
+
+func TestMatrixArithmetic(t *testing.T) {
+	a := mat(t, "[[1,2],[3,4]]")
+	b := mat(t, "[[5,6],[7,8]]")
+	c := mat(t, "[[1,2,3],[4,5,6]]")
+
+	tests := []struct {
+		name     string
+		fn       func() (Matrix, error)
+		expected string
+	}{
+		{"add", func() (Matrix, error) { return MatrixAdd(a, b) }, "[[6,8],[10,12]]"},
+		{"subtract", func() (Matrix, error) { return MatrixSubtract(a, b) }, "[[-4,-4],[-4,-4]]"},
+		{"multiply", func() (Matrix, error) { return MatrixMultiply(a, b) }, "[[19,22],[43,50]]"},
+		{"multiply rectangular", func() (Matrix, error) { return MatrixMultiply(a, c) }, "[[9,12,15],[19,26,33]]"},
+		{"scale", func() (Matrix, error) { return MatrixScale(a, 2), nil }, "[[2,4],[6,8]]"},
+		{"transpose", func() (Matrix, error) { return c.Transpose(), nil }, "[[1,4],[2,5],[3,6]]"},
+		{"identity", func() (Matrix, error) { return MatrixMultiply(Identity(2), a) }, "[[1,2],[3,4]]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.String() != tt.expected {
+				t.Errorf("got %s; want %s", got, tt.expected)
+			}
+		})
+	}
+
+	var shapeErr *ShapeError
+	if _, err := MatrixAdd(a, c); !errors.As(err, &shapeErr) || err.Error() != "cannot add 2x2 and 2x3" {
+		t.Errorf("MatrixAdd(2x2, 2x3) error = %v; want *ShapeError", err)
+	}
+	if _, err := MatrixMultiply(c, a); !errors.As(err, &shapeErr) {
+		t.Errorf("MatrixMultiply(2x3, 2x2) error = %v; want *ShapeError", err)
+	}
+}
+
+func TestMatrixVector(t *testing.T) {
+	a := mat(t, "[[1,2,3],[4,5,6]]")
+	v, err := a.MulVec(Vector{1, 0, -1})
+	if err != nil || v.String() != "[-2,-2]" {
+		t.Errorf("MulVec = %v, %v; want [-2,-2]", v, err)
+	}
+	if _, err := a.MulVec(Vector{1, 2}); err == nil {
+		t.Errorf("MulVec with wrong length expected error")
+	}
+
+	if dot, err := (Vector{1, 2, 3}).Dot(Vector{4, 5, 6}); err != nil || dot != 32 {
+		t.Errorf("Dot = %v, %v; want 32", dot, err)
+	}
+	if norm := (Vector{3, 4}).Norm(); norm != 5 {
+		t.Errorf("Norm = %v; want 5", norm)
+	}
+	if v, err := ParseVector("[1, 2/4, -3]"); err != nil || v.String() != "[1,0.5,-3]" {
+		t.Errorf("ParseVector = %v, %v; want [1,0.5,-3]", v, err)
+	}
+}
+
+func TestDeterminant(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+	}{
+		{"[[1,2],[3,4]]", -2},
+		{"[[2,0,0],[0,3,0],[0,0,4]]", 24},
+		{"[[0,1],[1,0]]", -1},
+		{"[[6,1,1],[4,-2,5],[2,8,7]]", -306},
+		{"[[1,2],[2,4]]", 0},
+		{"[[7]]", 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			det, err := mat(t, tt.input).Det()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if math.Abs(det-tt.expected) > 1e-9 {
+				t.Errorf("Det(%s) = %v; want %v", tt.input, det, tt.expected)
+			}
+		})
+	}
+
+	var shapeErr *ShapeError
+	if _, err := mat(t, "[[1,2,3]]").Det(); !errors.As(err, &shapeErr) {
+		t.Errorf("Det of a non-square matrix error = %v; want *ShapeError", err)
+	}
+}
+
+func TestInverse(t *testing.T) {
+	a := mat(t, "[[4,7],[2,6]]")
+	inv, err := a.Inverse()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := mat(t, "[[0.6,-0.7],[-0.2,0.4]]"); !inv.Equal(expected, 1e-12) {
+		t.Errorf("Inverse = %s; want %s", inv, expected)
+	}
+
+	b := mat(t, "[[0,2,1],[1,1,0],[3,0,1]]")
+	inv, err = b.Inverse()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if product, _ := MatrixMultiply(b, inv); !product.Equal(Identity(3), 1e-12) {
+		t.Errorf("B × Inverse(B) = %s; want identity", product)
+	}
+
+	for _, singular := range []string{"[[1,2],[2,4]]", "[[0,0],[0,0]]", "[[1,2,3],[4,5,6],[7,8,9]]", "[[1e-10,2e-10],[1e6,2e6]]"} {
+		if _, err := mat(t, singular).Inverse(); !errors.Is(err, ErrSingularMatrix) {
+			t.Errorf("Inverse(%s) error = %v; want ErrSingularMatrix", singular, err)
+		}
+	}
+
+	// Rows of very different scales are not singular
+	scaled := mat(t, "[[1e6,0],[0,1e-10]]")
+	if det, err := scaled.Det(); err != nil || math.Abs(det-1e-4) > 1e-16 {
+		t.Errorf("Det(%s) = %g, %v; want 1e-4", scaled, det, err)
+	}
+	if inv, err := scaled.Inverse(); err != nil || !inv.Equal(mat(t, "[[1e-6,0],[0,1e10]]"), 1e-3) {
+		t.Errorf("Inverse(%s) = %s, %v; want [[1e-6,0],[0,1e10]]", scaled, inv, err)
+	}
+}
+
+func TestLU(t *testing.T) {
+	a := mat(t, "[[1,2,0],[3,4,4],[5,6,3]]")
+	d, err := a.LU()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Rebuild P × A and compare it with L × U
+	rows := make([][]float64, a.Rows())
+	for i, p := range d.Perm {
+		rows[i] = a.Row(p)
+	}
+	pa, _ := NewMatrix(rows)
+	lu, _ := MatrixMultiply(d.L, d.U)
+	if !lu.Equal(pa, 1e-12) {
+		t.Errorf("L × U = %s; want P × A = %s", lu, pa)
+	}
+	for i := range a.Rows() {
+		if d.L.At(i, i) != 1 {
+			t.Errorf("L(%d, %d) = %v; want 1", i, i, d.L.At(i, i))
+		}
+		for j := range i {
+			if d.U.At(i, j) != 0 || d.L.At(j, i) != 0 {
+				t.Errorf("L = %s, U = %s are not triangular", d.L, d.U)
+			}
+		}
+	}
+	if det := d.Det(); math.Abs(det-10) > 1e-12 {
+		t.Errorf("Det() = %v; want 10", det)
+	}
+}
+
+func TestSolve(t *testing.T) {
+	a := mat(t, "[[2,1,-1],[-3,-1,2],[-2,1,2]]")
+	x, err := Solve(a, Vector{8, -11, -3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, want := range []float64{2, 3, -1} {
+		if math.Abs(x[i]-want) > 1e-12 {
+			t.Errorf("Solve = %v; want [2,3,-1]", x)
+			break
+		}
+	}
+
+	if _, err := Solve(mat(t, "[[1,1],[1,1]]"), Vector{1, 2}); !errors.Is(err, ErrSingularMatrix) {
+		t.Errorf("Solve with singular matrix error = %v; want ErrSingularMatrix", err)
+	}
+	var shapeErr *ShapeError
+	if _, err := Solve(a, Vector{1, 2}); !errors.As(err, &shapeErr) {
+		t.Errorf("Solve with wrong length error = %v; want *ShapeError", err)
+	}
+}
@@ -0,0 +1,433 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// epsilon is the spacing between 1 and the next float64
+const epsilon = 2.220446049250313e-16
+
+// ErrNoConvergence is wrapped by a *ConvergenceError when a method runs out of iterations
+var ErrNoConvergence = errors.New("no convergence")
+
+// ConvergenceError reports a numerical method that stopped before reaching its tolerance
+type ConvergenceError struct {
+	Method        string
+	Iterations    int
+	ErrorEstimate float64
+}
+
+func (e *ConvergenceError) Error() string {
+	return fmt.Sprintf("%s: no convergence after %d iterations (error estimate %g)", e.Method, e.Iterations, e.ErrorEstimate)
+}
+
+func (e *ConvergenceError) Unwrap() error {
+	return ErrNoConvergence
+}
+
+// Options controls when a numerical method stops
+// A zero Tolerance or MaxIterations selects the method's default
+type Options struct {
+	Tolerance     float64
+	MaxIterations int
+}
+
+func (o Options) withDefaults(tolerance float64, maxIterations int) Options {
+	if o.Tolerance <= 0 {
+		o.Tolerance = tolerance
+	}
+	if o.MaxIterations <= 0 {
+		o.MaxIterations = maxIterations
+	}
+	return o
+}
+
+// Result is the outcome of a numerical method
+// When Converged is false the method also returns an error and Value is its best estimate
+type Result struct {
+	Value         float64
+	Iterations    int
+	ErrorEstimate float64
+	Converged     bool
+}
+
+// notFinite reports a function value that cannot be used, such as a division by zero in an expression
+func notFinite(method string, x, fx float64) error {
+	return fmt.Errorf("%s: f(%g) = %g is not finite", method, x, fx)
+}
+
+// finish marks r as converged or returns the matching *ConvergenceError
+func finish(method string, r Result, converged bool) (Result, error) {
+	r.Converged = converged
+	if !converged {
+		return r, &ConvergenceError{Method: method, Iterations: r.Iterations, ErrorEstimate: r.ErrorEstimate}
+	}
+	return r, nil
+}
+
+// Bisection finds a root of f in [a, b] by repeatedly halving the interval
+// f(a) and f(b) must have opposite signs; the endpoints may be given in either order
+// Defaults: Tolerance 1e-10 on the interval half-width, 200 iterations
+func Bisection(f func(float64) float64, a, b float64, opts Options) (Result, error) {
+	opts = opts.withDefaults(1e-10, 200)
+	if a > b {
+		a, b = b, a
+	}
+	fa, fb := f(a), f(b)
+	if err := checkBracket("bisection", a, b, fa, fb); err != nil {
+		return Result{}, err
+	}
+	if fa == 0 {
+		return Result{Value: a, Converged: true}, nil
+	}
+	if fb == 0 {
+		return Result{Value: b, Converged: true}, nil
+	}
+
+	r := Result{}
+	for r.Iterations < opts.MaxIterations {
+		r.Iterations++
+		mid := a + (b-a)/2
+		fm := f(mid)
+		if math.IsNaN(fm) || math.IsInf(fm, 0) {
+			return r, notFinite("bisection", mid, fm)
+		}
+		r.Value, r.ErrorEstimate = mid, (b-a)/2
+		if fm == 0 || r.ErrorEstimate <= opts.Tolerance {
+			return finish("bisection", r, true)
+		}
+		if math.Signbit(fm) == math.Signbit(fa) {
+			a, fa = mid, fm
+		} else {
+			b = mid
+		}
+	}
+	return finish("bisection", r, false)
+}
+
+func checkBracket(method string, a, b, fa, fb float64) error {
+	for _, v := range [][2]float64{{a, fa}, {b, fb}} {
+		if math.IsNaN(v[1]) || math.IsInf(v[1], 0) {
+			return notFinite(method, v[0], v[1])
+		}
+	}
+	if fa != 0 && fb != 0 && math.Signbit(fa) == math.Signbit(fb) {
+		return fmt.Errorf("%s: f(%g) and f(%g) must have opposite signs", method, a, b)
+	}
+	return nil
+}
+
+// Newton finds a root of f starting from x0 using Newton's method
+// df is the derivative of f; if nil, it is approximated with central differences
+// Defaults: Tolerance 1e-10 on the step size, 100 iterations
+func Newton(f, df func(float64) float64, x0 float64, opts Options) (Result, error) {
+	opts = opts.withDefaults(1e-10, 100)
+	if df == nil {
+		df = centralDifference(f)
+	}
+
+	r := Result{Value: x0, ErrorEstimate: math.Inf(1)}
+	for r.Iterations < opts.MaxIterations {
+		r.Iterations++
+		fx, dfx := f(r.Value), df(r.Value)
+		if math.IsNaN(fx) || math.IsInf(fx, 0) {
+			return r, notFinite("newton", r.Value, fx)
+		}
+		if fx == 0 {
+			r.ErrorEstimate = 0
+			return finish("newton", r, true)
+		}
+		step, err := Divide(fx, dfx)
+		if err != nil {
+			return r, fmt.Errorf("newton: zero derivative at %g", r.Value)
+		}
+		r.Value -= step
+		r.ErrorEstimate = math.Abs(step)
+		if r.ErrorEstimate <= opts.Tolerance*math.Max(1, math.Abs(r.Value)) {
+			return finish("newton", r, true)
+		}
+	}
+	return finish("newton", r, false)
+}
+
+// NewtonExpr finds a root of the expression expr in variable starting from x0,
+// using the symbolic derivative from Derive
+func NewtonExpr(expr, variable string, x0 float64, opts Options) (Result, error) {
+	f, err := ExprFunc(expr, variable)
+	if err != nil {
+		return Result{}, err
+	}
+	d, err := Derive(expr, variable)
+	if err != nil {
+		return Result{}, err
+	}
+	df, err := ExprFunc(d.String(), variable)
+	if err != nil {
+		return Result{}, err
+	}
+	return Newton(f, df, x0, opts)
+}
+
+func centralDifference(f func(float64) float64) func(float64) float64 {
+	return func(x float64) float64 {
+		h := 1e-6 * math.Max(1, math.Abs(x))
+		return (f(x+h) - f(x-h)) / (2 * h)
+	}
+}
+
+// Brent finds a root of f in [a, b] combining bisection, secant and inverse quadratic interpolation
+// f(a) and f(b) must have opposite signs
+// Defaults: Tolerance 1e-10, 100 iterations
+func Brent(f func(float64) float64, a, b float64, opts Options) (Result, error) {
+	opts = opts.withDefaults(1e-10, 100)
+	fa, fb := f(a), f(b)
+	if err := checkBracket("brent", a, b, fa, fb); err != nil {
+		return Result{}, err
+	}
+
+	c, fc := a, fa
+	d := b - a
+	e := d
+	r := Result{Value: b}
+	for r.Iterations < opts.MaxIterations {
+		r.Iterations++
+		// Keep b as the best estimate and [b, c] as the bracket
+		if (fb > 0 && fc > 0) || (fb < 0 && fc < 0) {
+			c, fc = a, fa
+			d = b - a
+			e = d
+		}
+		if math.Abs(fc) < math.Abs(fb) {
+			a, b, c = b, c, b
+			fa, fb, fc = fb, fc, fb
+		}
+
+		tol := 2*epsilon*math.Abs(b) + 0.5*opts.Tolerance
+		m := 0.5 * (c - b)
+		r.Value, r.ErrorEstimate = b, math.Abs(m)
+		if math.Abs(m) <= tol || fb == 0 {
+			return finish("brent", r, true)
+		}
+
+		if math.Abs(e) >= tol && math.Abs(fa) > math.Abs(fb) {
+			var p, q float64
+			s := fb / fa
+			if a == c {
+				// Secant step
+				p = 2 * m * s
+				q = 1 - s
+			} else {
+				// Inverse quadratic interpolation
+				q0 := fa / fc
+				r0 := fb / fc
+				p = s * (2*m*q0*(q0-r0) - (b-a)*(r0-1))
+				q = (q0 - 1) * (r0 - 1) * (s - 1)
+			}
+			if p > 0 {
+				q = -q
+			} else {
+				p = -p
+			}
+			if 2*p < math.Min(3*m*q-math.Abs(tol*q), math.Abs(e*q)) {
+				e, d = d, p/q
+			} else {
+				d, e = m, m
+			}
+		} else {
+			d, e = m, m
+		}
+
+		a, fa = b, fb
+		if math.Abs(d) > tol {
+			b += d
+		} else {
+			b += math.Copysign(tol, m)
+		}
+		fb = f(b)
+		if math.IsNaN(fb) || math.IsInf(fb, 0) {
+			return r, notFinite("brent", b, fb)
+		}
+	}
+	return finish("brent", r, false)
+}
+
+// Simpson integrates f over [a, b] with adaptive Simpson's rule
+// Iterations counts the evaluations of f
+// Defaults: Tolerance 1e-10, 100000 evaluations
+func Simpson(f func(float64) float64, a, b float64, opts Options) (Result, error) {
+	opts = opts.withDefaults(1e-10, 100000)
+	s := &simpson{f: f, maxEvals: opts.MaxIterations}
+	fa, fm, fb := s.eval(a), s.eval((a+b)/2), s.eval(b)
+	whole := (b - a) / 6 * (fa + 4*fm + fb)
+	value, estimate := s.integrate(a, b, fa, fm, fb, whole, opts.Tolerance, 50)
+
+	r := Result{Value: value, Iterations: s.evals, ErrorEstimate: estimate}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return r, fmt.Errorf("simpson: integral is not finite")
+	}
+	return finish("simpson", r, !s.exhausted)
+}
+
+// simpson holds the state of an adaptive Simpson integration
+type simpson struct {
+	f         func(float64) float64
+	evals     int
+	maxEvals  int
+	exhausted bool
+}
+
+func (s *simpson) eval(x float64) float64 {
+	s.evals++
+	return s.f(x)
+}
+
+// integrate refines the Simpson estimate whole of [a, b] until it is within tol
+// It returns the integral and an estimate of its error
+func (s *simpson) integrate(a, b, fa, fm, fb, whole, tol float64, depth int) (float64, float64) {
+	m := (a + b) / 2
+	lm, rm := (a+m)/2, (m+b)/2
+	flm, frm := s.eval(lm), s.eval(rm)
+	left := (m - a) / 6 * (fa + 4*flm + fm)
+	right := (b - m) / 6 * (fm + 4*frm + fb)
+	diff := left + right - whole
+
+	if math.Abs(diff) <= 15*tol {
+		// Richardson extrapolation removes the leading error term
+		return left + right + diff/15, math.Abs(diff) / 15
+	}
+	if depth <= 0 || s.evals >= s.maxEvals {
+		s.exhausted = true
+		return left + right + diff/15, math.Abs(diff) / 15
+	}
+	lv, le := s.integrate(a, m, fa, flm, fm, left, tol/2, depth-1)
+	rv, re := s.integrate(m, b, fm, frm, fb, right, tol/2, depth-1)
+	return lv + rv, le + re
+}
+
+// GaussLegendre integrates f over [a, b] with Gauss–Legendre quadrature,
+// doubling the number of nodes from 4 until two successive results agree within Tolerance
+// Iterations counts the doublings
+// Defaults: Tolerance 1e-10, 8 doublings (up to 1024 nodes)
+func GaussLegendre(f func(float64) float64, a, b float64, opts Options) (Result, error) {
+	opts = opts.withDefaults(1e-10, 8)
+	n := 4
+	prev := gaussLegendre(f, a, b, n)
+	r := Result{Value: prev, ErrorEstimate: math.Inf(1)}
+	for r.Iterations < opts.MaxIterations {
+		r.Iterations++
+		n *= 2
+		next := gaussLegendre(f, a, b, n)
+		if math.IsNaN(next) || math.IsInf(next, 0) {
+			return r, fmt.Errorf("gauss-legendre: integral is not finite")
+		}
+		r.Value, r.ErrorEstimate = next, math.Abs(next-prev)
+		if r.ErrorEstimate <= opts.Tolerance*math.Max(1, math.Abs(next)) {
+			return finish("gauss-legendre", r, true)
+		}
+		prev = next
+	}
+	return finish("gauss-legendre", r, false)
+}
+
+// gaussLegendre applies the n-point Gauss–Legendre rule to f over [a, b]
+func gaussLegendre(f func(float64) float64, a, b float64, n int) float64 {
+	half, mid := (b-a)/2, (a+b)/2
+	sum := 0.0
+	for i := 0; i < (n+1)/2; i++ {
+		x, w := legendreNode(n, i)
+		if x == 0 {
+			sum += w * f(mid)
+			continue
+		}
+		sum += w * (f(mid-half*x) + f(mid+half*x))
+	}
+	return half * sum
+}
+
+// legendreNode returns the i-th positive root of the Legendre polynomial P_n and its weight
+func legendreNode(n, i int) (x, w float64) {
+	// Initial guess from the asymptotic formula, refined with Newton's method
+	x = math.Cos(math.Pi * (float64(i) + 0.75) / (float64(n) + 0.5))
+	var dp float64
+	for range 100 {
+		p0, p1 := 1.0, x
+		for k := 2; k <= n; k++ {
+			p0, p1 = p1, ((2*float64(k)-1)*x*p1-(float64(k)-1)*p0)/float64(k)
+		}
+		dp = float64(n) * (x*p1 - p0) / (x*x - 1)
+		dx := p1 / dp
+		x -= dx
+		if math.Abs(dx) < 1e-15 {
+			break
+		}
+	}
+	if n%2 == 1 && i == n/2 {
+		x = 0
+	}
+	return x, 2 / ((1 - x*x) * dp * dp)
+}
+
+// ExprFunc compiles an expression in one variable, e.g. ExprFunc("x^2 - 2", "x")
+// Built-in functions and constants are available; evaluation errors such as
+// division by zero produce NaN, which the numerical methods report as errors
+func ExprFunc(expr, variable string) (func(float64) float64, error) {
+	f, err := compileExpr(expr, variable)
+	if err != nil {
+		return nil, err
+	}
+	return func(x float64) float64 { return f(x) }, nil
+}
+
+// compileExpr parses expr once and returns a function of the named parameters
+// Unknown names are reported immediately as *UndefinedError
+func compileExpr(expr string, params ...string) (func(args ...float64) float64, error) {
+	n, err := Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkNames(n, params); err != nil {
+		return nil, err
+	}
+	return func(args ...float64) float64 {
+		locals := make(map[string]float64, len(params))
+		for i, p := range params {
+			locals[p] = args[i]
+		}
+		v, err := evaluator{locals: locals}.eval(n)
+		if err != nil {
+			return math.NaN()
+		}
+		return v
+	}, nil
+}
+
+// checkNames returns an *UndefinedError for the first name in n that is neither a parameter,
+// a constant nor a built-in function
+func checkNames(n Node, params []string) error {
+	switch n := n.(type) {
+	case *Ident:
+		if _, ok := constants[n.Name]; !ok && !slices.Contains(params, n.Name) {
+			return &UndefinedError{Kind: "variable", Name: n.Name, Col: n.Pos()}
+		}
+	case *Call:
+		if _, ok := builtins[n.Name]; !ok {
+			return &UndefinedError{Kind: "function", Name: n.Name, Col: n.Pos()}
+		}
+		for _, arg := range n.Args {
+			if err := checkNames(arg, params); err != nil {
+				return err
+			}
+		}
+	case *Unary:
+		return checkNames(n.X, params)
+	case *Binary:
+		if err := checkNames(n.X, params); err != nil {
+			return err
+		}
+		return checkNames(n.Y, params)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package calculator
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestRootFinding(t *testing.T) {
+	f := func(x float64) float64 { return x*x - 2 }
+	df := func(x float64) float64 { return 2 * x }
+
+	tests := []struct {
+		name  string
+		solve func() (Result, error)
+	}{
+		{"bisection", func() (Result, error) { return Bisection(f, 0, 2, Options{}) }},
+		{"newton", func() (Result, error) { return Newton(f, df, 1, Options{}) }},
+		{"newton numerical derivative", func() (Result, error) { return Newton(f, nil, 1, Options{}) }},
+		{"brent", func() (Result, error) { return Brent(f, 0, 2, Options{}) }},
+		{"bisection reversed bracket", func() (Result, error) { return Bisection(f, 2, 0, Options{}) }},
+		{"brent reversed bracket", func() (Result, error) { return Brent(f, 2, 0, Options{}) }},
+		{"newton expression", func() (Result, error) { return NewtonExpr("x^2 - 2", "x", 1, Options{}) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := tt.solve()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !r.Converged || math.Abs(r.Value-math.Sqrt2) > 1e-9 {
+				t.Errorf("root = %v (converged %v); want √2", r.Value, r.Converged)
+			}
+			if r.Iterations == 0 || r.ErrorEstimate > 1e-9 {
+				t.Errorf("iterations = %d, error estimate = %g", r.Iterations, r.ErrorEstimate)
+			}
+		})
+	}
+}
+
+func TestBisectionReversedBracket(t *testing.T) {
+	r, err := Bisection(func(x float64) float64 { return x - 0.3 }, 1, 0, Options{})
+	if err != nil || math.Abs(r.Value-0.3) > 1e-9 || r.ErrorEstimate < 0 || r.Iterations < 2 {
+		t.Errorf("Bisection(x - 0.3, 1, 0) = %+v, %v; want a root at 0.3", r, err)
+	}
+}
+
+func TestRootFindingIterations(t *testing.T) {
+	f, _ := ExprFunc("cos(x) - x", "x")
+	bisection, _ := Bisection(f, 0, 1, Options{})
+	brent, _ := Brent(f, 0, 1, Options{})
+	newton, _ := Newton(f, nil, 0.5, Options{})
+	if math.Abs(brent.Value-0.7390851332151607) > 1e-9 || math.Abs(newton.Value-brent.Value) > 1e-9 {
+		t.Errorf("brent = %v, newton = %v; want 0.739085", brent.Value, newton.Value)
+	}
+	if brent.Iterations >= bisection.Iterations || newton.Iterations >= bisection.Iterations {
+		t.Errorf("iterations: bisection %d, brent %d, newton %d; expected the faster methods to need fewer",
+			bisection.Iterations, brent.Iterations, newton.Iterations)
+	}
+}
+
+func TestRootFindingErrors(t *testing.T) {
+	f := func(x float64) float64 { return x*x + 1 }
+
+	if _, err := Bisection(f, -1, 1, Options{}); err == nil {
+		t.Errorf("Bisection without a sign change expected error")
+	}
+	if _, err := Brent(f, -1, 1, Options{}); err == nil {
+		t.Errorf("Brent without a sign change expected error")
+	}
+
+	r, err := Newton(f, nil, 0.5, Options{MaxIterations: 20})
+	var convErr *ConvergenceError
+	if !errors.Is(err, ErrNoConvergence) || !errors.As(err, &convErr) {
+		t.Fatalf("Newton on x^2+1 error = %v; want ErrNoConvergence", err)
+	}
+	if r.Converged || r.Iterations != 20 || convErr.Iterations != 20 {
+		t.Errorf("result = %+v, error = %+v", r, convErr)
+	}
+
+	if _, err := Newton(func(x float64) float64 { return x*x - 1 }, func(float64) float64 { return 0 }, 3, Options{}); err == nil {
+		t.Errorf("Newton with zero derivative expected error")
+	}
+	if _, err := Bisection(func(x float64) float64 { return 1 / x }, -1, 1, Options{}); err == nil {
+		t.Errorf("Bisection across a pole expected error")
+	}
+	if _, err := NewtonExpr("x^2 - k", "x", 1, Options{}); err == nil {
+		t.Errorf("NewtonExpr with undefined name expected error")
+	}
+}
+
+func TestIntegration(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		a, b     float64
+		expected float64
+	}{
+		{"polynomial", "x^3 - 2*x", 0, 2, 0},
+		{"sine", "sin(x)", 0, math.Pi, 2},
+		{"gaussian", "exp(-x^2)", -5, 5, math.Sqrt(math.Pi) * math.Erf(5)},
+		{"reciprocal", "1 / x", 1, math.E, 1},
+		{"square root", "sqrt(x)", 0, 1, 2.0 / 3},
+	}
+
+	for _, tt := range tests {
+		f, err := ExprFunc(tt.expr, "x")
+		if err != nil {
+			t.Fatalf("ExprFunc(%q) unexpected error: %v", tt.expr, err)
+		}
+		t.Run("simpson "+tt.name, func(t *testing.T) {
+			r, err := Simpson(f, tt.a, tt.b, Options{Tolerance: 1e-10})
+			if err != nil || math.Abs(r.Value-tt.expected) > 1e-8 {
+				t.Errorf("Simpson = %v, %v; want %v", r.Value, err, tt.expected)
+			}
+			if r.Iterations < 5 {
+				t.Errorf("Simpson reported %d evaluations", r.Iterations)
+			}
+		})
+		t.Run("gauss-legendre "+tt.name, func(t *testing.T) {
+			r, err := GaussLegendre(f, tt.a, tt.b, Options{Tolerance: 1e-9})
+			if err != nil || math.Abs(r.Value-tt.expected) > 1e-8 {
+				t.Errorf("GaussLegendre = %v, %v; want %v", r.Value, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIntegrationErrors(t *testing.T) {
+	f, _ := ExprFunc("sin(1 / x)", "x")
+	if r, err := Simpson(f, 1e-4, 1, Options{Tolerance: 1e-14, MaxIterations: 200}); !errors.Is(err, ErrNoConvergence) || r.Converged {
+		t.Errorf("Simpson with evaluation limit = %+v, %v; want ErrNoConvergence", r, err)
+	}
+	if r, err := GaussLegendre(f, 1e-4, 1, Options{Tolerance: 1e-14, MaxIterations: 2}); !errors.Is(err, ErrNoConvergence) || r.Iterations != 2 {
+		t.Errorf("GaussLegendre with doubling limit = %+v, %v; want ErrNoConvergence", r, err)
+	}
+}
+
+func TestLegendreNodes(t *testing.T) {
+	// The n-point rule integrates polynomials up to degree 2n-1 exactly
+	for _, n := range []int{1, 2, 3, 5, 8} {
+		degree := float64(2*n - 1)
+		got := gaussLegendre(func(x float64) float64 { return math.Pow(x, degree) + 1 }, 0, 1, n)
+		if want := 1/(degree+1) + 1; math.Abs(got-want) > 1e-12 {
+			t.Errorf("%d-point rule on x^%v + 1 = %v; want %v", n, degree, got, want)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// ODEFunc is the right-hand side of the system y' = f(t, y)
+type ODEFunc func(t float64, y []float64) []float64
+
+// ODEResult is the solution of an initial value problem
+// T holds the times of every step and Y the state at each of them
+type ODEResult struct {
+	T             []float64
+	Y             [][]float64
+	Steps         int
+	ErrorEstimate float64
+	Converged     bool
+}
+
+// Final returns the state at the last time reached
+func (r ODEResult) Final() []float64 {
+	if len(r.Y) == 0 {
+		return nil
+	}
+	return r.Y[len(r.Y)-1]
+}
+
+// RK4 solves y' = f(t, y) from t0 to t1 with the classic Runge–Kutta method in a fixed number of steps
+// ErrorEstimate compares the final state with a second run at half the step size
+func RK4(f ODEFunc, t0 float64, y0 []float64, t1 float64, steps int) (ODEResult, error) {
+	if steps <= 0 {
+		return ODEResult{}, fmt.Errorf("rk4: steps must be positive, got %d", steps)
+	}
+	if err := checkDimension("rk4", f, t0, y0); err != nil {
+		return ODEResult{}, err
+	}
+
+	r, err := rk4(f, t0, y0, t1, steps, true)
+	if err != nil {
+		return r, err
+	}
+	fine, err := rk4(f, t0, y0, t1, 2*steps, false)
+	if err != nil {
+		return r, err
+	}
+	// Richardson estimate for a fourth-order method
+	r.ErrorEstimate = maxDiff(r.Final(), fine.Final()) / 15
+	r.Converged = true
+	return r, nil
+}
+
+func rk4(f ODEFunc, t0 float64, y0 []float64, t1 float64, steps int, record bool) (ODEResult, error) {
+	h := (t1 - t0) / float64(steps)
+	t, y := t0, append([]float64(nil), y0...)
+	r := ODEResult{T: []float64{t}, Y: [][]float64{y}}
+
+	for i := range steps {
+		k1 := f(t, y)
+		k2 := f(t+h/2, axpy(y, h/2, k1))
+		k3 := f(t+h/2, axpy(y, h/2, k2))
+		k4 := f(t+h, axpy(y, h, k3))
+		next := make([]float64, len(y))
+		for j := range y {
+			next[j] = y[j] + h/6*(k1[j]+2*k2[j]+2*k3[j]+k4[j])
+		}
+		if !allFinite(next) {
+			return r, fmt.Errorf("rk4: solution is not finite at t = %g", t+h)
+		}
+
+		t, y = t0+float64(i+1)*h, next
+		r.Steps++
+		if record || i == steps-1 {
+			r.T = append(r.T, t)
+			r.Y = append(r.Y, y)
+		}
+	}
+	return r, nil
+}
+
+// Dormand–Prince coefficients for the embedded RK5(4) pair
+var (
+	dpC = [7]float64{0, 1.0 / 5, 3.0 / 10, 4.0 / 5, 8.0 / 9, 1, 1}
+	dpA = [7][6]float64{
+		{},
+		{1.0 / 5},
+		{3.0 / 40, 9.0 / 40},
+		{44.0 / 45, -56.0 / 15, 32.0 / 9},
+		{19372.0 / 6561, -25360.0 / 2187, 64448.0 / 6561, -212.0 / 729},
+		{9017.0 / 3168, -355.0 / 33, 46732.0 / 5247, 49.0 / 176, -5103.0 / 18656},
+		{35.0 / 384, 0, 500.0 / 1113, 125.0 / 192, -2187.0 / 6784, 11.0 / 84},
+	}
+	dpB5 = [7]float64{35.0 / 384, 0, 500.0 / 1113, 125.0 / 192, -2187.0 / 6784, 11.0 / 84, 0}
+	dpB4 = [7]float64{5179.0 / 57600, 0, 7571.0 / 16695, 393.0 / 640, -92097.0 / 339200, 187.0 / 2100, 1.0 / 40}
+)
+
+// RK45 solves y' = f(t, y) from t0 to t1 with the adaptive Dormand–Prince method
+// The step size is chosen so the local error stays below Tolerance (absolute and relative)
+// MaxIterations limits the number of attempted steps
+// Defaults: Tolerance 1e-8, 100000 steps
+func RK45(f ODEFunc, t0 float64, y0 []float64, t1 float64, opts Options) (ODEResult, error) {
+	opts = opts.withDefaults(1e-8, 100000)
+	if err := checkDimension("rk45", f, t0, y0); err != nil {
+		return ODEResult{}, err
+	}
+	t, y := t0, append([]float64(nil), y0...)
+	r := ODEResult{T: []float64{t}, Y: [][]float64{y}}
+	h := (t1 - t0) / 100
+	if h == 0 {
+		r.Converged = true
+		return r, nil
+	}
+
+	var k [7][]float64
+	for attempts := 0; (t1-t)*h > 0; attempts++ {
+		if attempts >= opts.MaxIterations {
+			return r, &ConvergenceError{Method: "rk45", Iterations: attempts, ErrorEstimate: r.ErrorEstimate}
+		}
+		if (t+h-t1)*h > 0 {
+			h = t1 - t
+		}
+
+		for i := range k {
+			yi := append([]float64(nil), y...)
+			for j := range i {
+				for n := range yi {
+					yi[n] += h * dpA[i][j] * k[j][n]
+				}
+			}
+			k[i] = f(t+dpC[i]*h, yi)
+		}
+
+		next := make([]float64, len(y))
+		errNorm := 0.0
+		for n := range y {
+			var y5, y4 float64
+			for i := range k {
+				y5 += dpB5[i] * k[i][n]
+				y4 += dpB4[i] * k[i][n]
+			}
+			next[n] = y[n] + h*y5
+			scale := opts.Tolerance * (1 + math.Max(math.Abs(y[n]), math.Abs(next[n])))
+			errNorm = math.Max(errNorm, math.Abs(h*(y5-y4))/scale)
+		}
+		if !allFinite(next) {
+			return r, fmt.Errorf("rk45: solution is not finite at t = %g", t+h)
+		}
+
+		if errNorm <= 1 {
+			t, y = t+h, next
+			r.T = append(r.T, t)
+			r.Y = append(r.Y, y)
+			r.Steps++
+			r.ErrorEstimate += errNorm * opts.Tolerance
+		}
+
+		// Standard step-size controller with a safety factor, growing at most 5x and shrinking at most 5x
+		factor := 5.0
+		if errNorm > 0 {
+			factor = math.Min(5, math.Max(0.2, 0.9*math.Pow(errNorm, -0.2)))
+		}
+		h *= factor
+		if math.Abs(h) < 1e-14*math.Max(1, math.Abs(t)) {
+			return r, &ConvergenceError{Method: "rk45", Iterations: attempts, ErrorEstimate: r.ErrorEstimate}
+		}
+	}
+
+	r.Converged = true
+	return r, nil
+}
+
+// ExprODE compiles expressions into an ODEFunc
+// Each expression is the derivative of one component; t is the time, and the state is
+// y for a single equation or y1, y2, ... for a system, e.g. ExprODE("y2", "-y1")
+func ExprODE(exprs ...string) (ODEFunc, error) {
+	if len(exprs) == 0 {
+		return nil, fmt.Errorf("no equations")
+	}
+	names := []string{"t", "y"}
+	if len(exprs) > 1 {
+		names = names[:1]
+		for i := range exprs {
+			names = append(names, "y"+strconv.Itoa(i+1))
+		}
+	}
+
+	funcs := make([]func(args ...float64) float64, len(exprs))
+	for i, expr := range exprs {
+		f, err := compileExpr(expr, names...)
+		if err != nil {
+			return nil, fmt.Errorf("equation %d: %w", i+1, err)
+		}
+		funcs[i] = f
+	}
+
+	return func(t float64, y []float64) []float64 {
+		if len(y) != len(funcs) {
+			// Let the solvers report the mismatch instead of indexing past the state
+			return nil
+		}
+		args := append([]float64{t}, y...)
+		dy := make([]float64, len(funcs))
+		for i, f := range funcs {
+			dy[i] = f(args...)
+		}
+		return dy
+	}, nil
+}
+
+// checkDimension evaluates f once at the initial point so a system whose derivative does not
+// match the size of the state fails with an error instead of panicking inside a step
+func checkDimension(method string, f ODEFunc, t0 float64, y0 []float64) error {
+	if dy := f(t0, y0); len(dy) != len(y0) {
+		return fmt.Errorf("%s: derivative has %d components for a state of %d", method, len(dy), len(y0))
+	}
+	return nil
+}
+
+// axpy returns y + a*x
+func axpy(y []float64, a float64, x []float64) []float64 {
+	out := make([]float64, len(y))
+	for i := range y {
+		out[i] = y[i] + a*x[i]
+	}
+	return out
+}
+
+func maxDiff(a, b []float64) float64 {
+	m := 0.0
+	for i := range a {
+		m = math.Max(m, math.Abs(a[i]-b[i]))
+	}
+	return m
+}
+
+func allFinite(v []float64) bool {
+	for _, x := range v {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+package calculator
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestRK4(t *testing.T) {
+	// y' = y, y(0) = 1 has solution e^t
+	f, err := ExprODE("y")
+	if err != nil {
+		t.Fatalf("ExprODE unexpected error: %v", err)
+	}
+	r, err := RK4(f, 0, []float64{1}, 1, 100)
+	if err != nil {
+		t.Fatalf("RK4 unexpected error: %v", err)
+	}
+	if got := r.Final()[0]; math.Abs(got-math.E) > 1e-8 {
+		t.Errorf("RK4 y(1) = %v; want e", got)
+	}
+	if len(r.T) != 101 || r.Steps != 100 || r.T[100] != 1 || !r.Converged {
+		t.Errorf("RK4 recorded %d times over %d steps", len(r.T), r.Steps)
+	}
+	if actual := math.Abs(r.Final()[0] - math.E); r.ErrorEstimate <= 0 || r.ErrorEstimate > 10*actual {
+		t.Errorf("RK4 error estimate %g for actual error %g", r.ErrorEstimate, actual)
+	}
+
+	if _, err := RK4(f, 0, []float64{1}, 1, 0); err == nil {
+		t.Errorf("RK4 with zero steps expected error")
+	}
+}
+
+func TestRK45(t *testing.T) {
+	// Harmonic oscillator y1' = y2, y2' = -y1 with y(0) = (0, 1): y1 = sin t, y2 = cos t
+	oscillator := func(t float64, y []float64) []float64 { return []float64{y[1], -y[0]} }
+	fromExpr, err := ExprODE("y2", "-y1")
+	if err != nil {
+		t.Fatalf("ExprODE unexpected error: %v", err)
+	}
+
+	for name, f := range map[string]ODEFunc{"go func": oscillator, "expression": fromExpr} {
+		t.Run(name, func(t *testing.T) {
+			r, err := RK45(f, 0, []float64{0, 1}, 2*math.Pi, Options{Tolerance: 1e-10})
+			if err != nil {
+				t.Fatalf("RK45 unexpected error: %v", err)
+			}
+			final := r.Final()
+			if math.Abs(final[0]) > 1e-7 || math.Abs(final[1]-1) > 1e-7 {
+				t.Errorf("RK45 y(2π) = %v; want (0, 1)", final)
+			}
+			if r.T[len(r.T)-1] != 2*math.Pi || !r.Converged || r.Steps != len(r.T)-1 {
+				t.Errorf("RK45 ended at t = %v after %d steps", r.T[len(r.T)-1], r.Steps)
+			}
+		})
+	}
+}
+
+func TestRK45Backwards(t *testing.T) {
+	f, _ := ExprODE("-2 * t * y")
+	r, err := RK45(f, 1, []float64{math.Exp(-1)}, 0, Options{})
+	if err != nil {
+		t.Fatalf("RK45 unexpected error: %v", err)
+	}
+	if got := r.Final()[0]; math.Abs(got-1) > 1e-7 {
+		t.Errorf("RK45 integrating backwards y(0) = %v; want 1", got)
+	}
+}
+
+func TestODEErrors(t *testing.T) {
+	blowUp, _ := ExprODE("y^2")
+	if _, err := RK45(blowUp, 0, []float64{1}, 2, Options{MaxIterations: 500}); err == nil {
+		t.Errorf("RK45 through a singularity expected error")
+	}
+
+	f, _ := ExprODE("y")
+	if _, err := RK45(f, 0, []float64{1}, 10, Options{MaxIterations: 3}); !errors.Is(err, ErrNoConvergence) {
+		t.Errorf("RK45 with step limit error = %v; want ErrNoConvergence", err)
+	}
+
+	system, _ := ExprODE("y2", "-y1")
+	if _, err := RK4(system, 0, []float64{1}, 1, 10); err == nil {
+		t.Errorf("RK4 with a short initial state expected error")
+	}
+	if _, err := RK45(system, 0, []float64{1, 0, 0}, 1, Options{}); err == nil {
+		t.Errorf("RK45 with a long initial state expected error")
+	}
+	wrong := func(t float64, y []float64) []float64 { return []float64{1} }
+	if _, err := RK45(wrong, 0, []float64{1, 2}, 1, Options{}); err == nil {
+		t.Errorf("RK45 with a mismatched ODEFunc expected error")
+	}
+
+	var undefined *UndefinedError
+	if _, err := ExprODE("y + z"); !errors.As(err, &undefined) {
+		t.Errorf("ExprODE with unknown name error = %v; want *UndefinedError", err)
+	}
+	if _, err := ExprODE(); err == nil {
+		t.Errorf("ExprODE without equations expected error")
+	}
+}
@@ -0,0 +1,389 @@
+package calculator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Operator precedence levels, from loosest to tightest binding
+const (
+	precAdditive = iota + 1
+	precMultiplicative
+	precImplicit
+	precUnary
+	precPower
+	precAtom
+)
+
+// Node is an element of a parsed expression tree
+type Node interface {
+	// Pos returns the 1-based column where the node starts, or 0 if unknown
+	Pos() int
+	// String returns the node in infix notation
+	String() string
+}
+
+// position records the column of a node in the source expression
+type position int
+
+func (p position) Pos() int { return int(p) }
+
+// Number is a numeric literal
+type Number struct {
+	position
+	Value float64
+}
+
+// Imaginary is an imaginary literal such as 4i
+type Imaginary struct {
+	position
+	Value float64
+}
+
+// Unary is a prefix operation such as -x
+type Unary struct {
+	position
+	Op rune
+	X  Node
+}
+
+// Binary is an infix operation such as x + y
+type Binary struct {
+	position
+	Op   rune
+	X, Y Node
+}
+
+// Ident is a reference to a variable, constant or function parameter
+type Ident struct {
+	position
+	Name string
+}
+
+// Call is a function call such as max(a, b)
+type Call struct {
+	position
+	Name string
+	Args []Node
+}
+
+// Parse parses an infix expression into a tree
+// Supports + - * / ^, unary minus, parentheses, names and function calls
+func Parse(expr string) (Node, error) {
+	return parse(expr, false)
+}
+
+// parse parses an expression, multiplying juxtaposed operands when implicit is true
+func parse(expr string, implicit bool) (Node, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens, implicit: implicit}
+	return p.parseRest()
+}
+
+// parser is a precedence-climbing parser over a token slice
+// With implicit set, juxtaposition multiplies and binds tighter than * and /, so 1/2x is 1/(2*x)
+type parser struct {
+	tokens   []token
+	pos      int
+	implicit bool
+}
+
+// parseRest parses the remaining tokens as a single expression
+func (p *parser) parseRest() (Node, error) {
+	n, err := p.parseExpr(precAdditive)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, unexpected(tok)
+	}
+	return n, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// parseExpr parses a chain of binary operators binding at least as tight as minPrec
+func (p *parser) parseExpr(minPrec int) (Node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok := p.peek()
+		prec := binaryPrec(tok)
+		// A name or parenthesis right after an operand multiplies it, as in 2x or 5 km
+		implicit := p.implicit && prec == 0 && (tok.kind == tokIdent || tok.kind == tokLParen)
+		if implicit {
+			prec = precImplicit
+		}
+		if prec == 0 || prec < minPrec {
+			return left, nil
+		}
+		if implicit {
+			tok = token{kind: tokOperator, text: "*", col: tok.col}
+		} else {
+			p.next()
+		}
+
+		// ^ is right-associative: 2^3^2 is 2^(3^2)
+		nextMin := prec + 1
+		if tok.text == "^" {
+			nextMin = prec
+		}
+		right, err := p.parseExpr(nextMin)
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{position: position(tok.col), Op: rune(tok.text[0]), X: left, Y: right}
+	}
+}
+
+// parseUnary parses an optional prefix sign followed by its operand
+// The sign binds looser than ^, so -2^2 is -(2^2)
+func (p *parser) parseUnary() (Node, error) {
+	tok := p.peek()
+	if tok.kind == tokOperator && (tok.text == "-" || tok.text == "+") {
+		p.next()
+		x, err := p.parseExpr(precPower)
+		if err != nil {
+			return nil, err
+		}
+		return &Unary{position: position(tok.col), Op: rune(tok.text[0]), X: x}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokNumber:
+		v, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, &SyntaxError{Col: tok.col, Msg: fmt.Sprintf("invalid number '%s'", tok.text)}
+		}
+		return &Number{position: position(tok.col), Value: v}, nil
+	case tokImaginary:
+		v, err := strconv.ParseFloat(strings.TrimSuffix(tok.text, "i"), 64)
+		if err != nil {
+			return nil, &SyntaxError{Col: tok.col, Msg: fmt.Sprintf("invalid number '%s'", tok.text)}
+		}
+		return &Imaginary{position: position(tok.col), Value: v}, nil
+	case tokIdent:
+		if p.peek().kind == tokLParen {
+			return p.parseCall(tok)
+		}
+		return &Ident{position: position(tok.col), Name: tok.text}, nil
+	case tokLParen:
+		n, err := p.parseExpr(precAdditive)
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, &SyntaxError{Col: closing.col, Msg: fmt.Sprintf("expected ')', found %s", closing.describe())}
+		}
+		return n, nil
+	default:
+		return nil, unexpected(tok)
+	}
+}
+
+// parseCall parses the argument list of a call to the function named by tok
+func (p *parser) parseCall(name token) (Node, error) {
+	p.next()
+	call := &Call{position: position(name.col), Name: name.text}
+	if p.peek().kind == tokRParen {
+		p.next()
+		return call, nil
+	}
+
+	for {
+		arg, err := p.parseExpr(precAdditive)
+		if err != nil {
+			return nil, err
+		}
+		call.Args = append(call.Args, arg)
+
+		switch tok := p.next(); tok.kind {
+		case tokComma:
+		case tokRParen:
+			return call, nil
+		default:
+			return nil, &SyntaxError{Col: tok.col, Msg: fmt.Sprintf("expected ',' or ')', found %s", tok.describe())}
+		}
+	}
+}
+
+// statement is a parsed line of Env input
+// name is empty for a bare expression; params is non-nil for a function definition
+type statement struct {
+	name   string
+	params []string
+	body   Node
+}
+
+// parseStatement parses "name = expr", "name(a, b) = expr" or a bare expression
+func parseStatement(input string) (*statement, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	st := &statement{}
+	if p.peek().kind == tokIdent {
+		switch tokens[1].kind {
+		case tokAssign:
+			st.name = p.next().text
+			p.next()
+		case tokLParen:
+			if params, ok, err := p.parseParams(); err != nil {
+				return nil, err
+			} else if ok {
+				st.name, st.params = tokens[0].text, params
+			}
+		}
+	}
+
+	if st.body, err = p.parseRest(); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+// parseParams parses "name(a, b) =" if the tokens form a function header
+// When they don't, the parser is rewound and ok is false
+func (p *parser) parseParams() (params []string, ok bool, err error) {
+	start := p.pos
+	p.next()
+	p.next()
+
+	params = []string{}
+	seen := map[string]bool{}
+	for p.peek().kind != tokRParen {
+		if len(params) > 0 {
+			if p.peek().kind != tokComma {
+				p.pos = start
+				return nil, false, nil
+			}
+			p.next()
+		}
+		tok := p.next()
+		if tok.kind != tokIdent {
+			p.pos = start
+			return nil, false, nil
+		}
+		if seen[tok.text] {
+			return nil, false, &SyntaxError{Col: tok.col, Msg: fmt.Sprintf("duplicate parameter '%s'", tok.text)}
+		}
+		seen[tok.text] = true
+		params = append(params, tok.text)
+	}
+	p.next()
+
+	if p.peek().kind != tokAssign {
+		p.pos = start
+		return nil, false, nil
+	}
+	p.next()
+	return params, true, nil
+}
+
+// binaryPrec returns the precedence of tok as a binary operator, or 0 if it is not one
+func binaryPrec(tok token) int {
+	if tok.kind != tokOperator {
+		return 0
+	}
+	switch tok.text {
+	case "+", "-":
+		return precAdditive
+	case "*", "/":
+		return precMultiplicative
+	case "^":
+		return precPower
+	}
+	return 0
+}
+
+func unexpected(tok token) error {
+	return &SyntaxError{Col: tok.col, Msg: "unexpected " + tok.describe()}
+}
+
+// nodePrec returns how tightly a node binds when printed
+func nodePrec(n Node) int {
+	switch n := n.(type) {
+	case *Number:
+		if n.Value < 0 {
+			return precUnary
+		}
+	case *Imaginary:
+		if n.Value < 0 {
+			return precUnary
+		}
+	case *Unary:
+		return precUnary
+	case *Binary:
+		return binaryPrec(token{kind: tokOperator, text: string(n.Op)})
+	}
+	return precAtom
+}
+
+// wrap formats n, adding parentheses when it binds looser than prec
+func wrap(n Node, prec int) string {
+	if nodePrec(n) < prec {
+		return "(" + n.String() + ")"
+	}
+	return n.String()
+}
+
+func (n *Number) String() string {
+	return strconv.FormatFloat(n.Value, 'g', -1, 64)
+}
+
+func (n *Imaginary) String() string {
+	return strconv.FormatFloat(n.Value, 'g', -1, 64) + "i"
+}
+
+func (n *Ident) String() string {
+	return n.Name
+}
+
+func (n *Call) String() string {
+	args := make([]string, len(n.Args))
+	for i, arg := range n.Args {
+		args[i] = arg.String()
+	}
+	return n.Name + "(" + strings.Join(args, ", ") + ")"
+}
+
+func (n *Unary) String() string {
+	return string(n.Op) + wrap(n.X, precPower)
+}
+
+func (n *Binary) String() string {
+	prec := nodePrec(n)
+	leftPrec, rightPrec := prec, prec+1
+	if n.Op == '^' {
+		leftPrec, rightPrec = prec+1, prec
+	}
+	op := " " + string(n.Op) + " "
+	if n.Op == '^' {
+		op = "^"
+	}
+	return wrap(n.X, leftPrec) + op + wrap(n.Y, rightPrec)
+}
@@ -0,0 +1,378 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+	"strings"
+)
+
+// ErrOverflow is wrapped by an *OverflowError when a result does not fit its integer type
+var ErrOverflow = errors.New("integer overflow")
+
+// OverflowError reports an integer operation whose result does not fit its type
+type OverflowError struct {
+	Op   string
+	Type IntType
+}
+
+func (e *OverflowError) Error() string {
+	return fmt.Sprintf("%s overflows %s", e.Op, e.Type)
+}
+
+func (e *OverflowError) Unwrap() error {
+	return ErrOverflow
+}
+
+// ParseInt parses an integer literal such as "255", "-0xFF", "0o17", "0b1010" or "1_000_000"
+func ParseInt(s string) (*big.Int, error) {
+	x, ok := new(big.Int).SetString(strings.TrimSpace(s), 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer %q", s)
+	}
+	return x, nil
+}
+
+// ParseIntBase parses an integer written in base 2 to 62 without a prefix, e.g. ParseIntBase("zz", 36) is 1295
+// Up to base 36 letters may be either case; above it, lowercase letters come before uppercase ones
+func ParseIntBase(s string, base int) (*big.Int, error) {
+	if err := checkBase(base); err != nil {
+		return nil, err
+	}
+	x, ok := new(big.Int).SetString(strings.TrimSpace(s), base)
+	if !ok {
+		return nil, fmt.Errorf("invalid base %d integer %q", base, s)
+	}
+	return x, nil
+}
+
+// FormatInt formats x in base 2 to 62
+// Bases 2, 8 and 16 get the prefixes 0b, 0o and 0x so ParseInt reads them back, e.g. "-0xff"
+func FormatInt(x *big.Int, base int) (string, error) {
+	if err := checkBase(base); err != nil {
+		return "", err
+	}
+	prefix := map[int]string{2: "0b", 8: "0o", 16: "0x"}[base]
+	text := new(big.Int).Abs(x).Text(base)
+	if x.Sign() < 0 {
+		return "-" + prefix + text, nil
+	}
+	return prefix + text, nil
+}
+
+func checkBase(base int) error {
+	if base < 2 || base > big.MaxBase {
+		return fmt.Errorf("base must be between 2 and %d, got %d", big.MaxBase, base)
+	}
+	return nil
+}
+
+// Width is the number of bits of a fixed-size integer
+type Width int
+
+const (
+	Width8  Width = 8
+	Width16 Width = 16
+	Width32 Width = 32
+	Width64 Width = 64
+)
+
+// IntType is an integer type of a given width, signed (two's complement) or unsigned
+// A zero Width is an unbounded signed integer that never overflows
+type IntType struct {
+	Width  Width
+	Signed bool
+}
+
+// Integer types matching Go's fixed-size integers, and BigInt for unbounded integers
+var (
+	Int8   = IntType{Width8, true}
+	Int16  = IntType{Width16, true}
+	Int32  = IntType{Width32, true}
+	Int64  = IntType{Width64, true}
+	Uint8  = IntType{Width8, false}
+	Uint16 = IntType{Width16, false}
+	Uint32 = IntType{Width32, false}
+	Uint64 = IntType{Width64, false}
+	BigInt = IntType{0, true}
+)
+
+// String returns the Go name of the type, e.g. "int8", "uint64" or "bigint"
+func (t IntType) String() string {
+	switch {
+	case t.Width == 0:
+		return "bigint"
+	case t.Signed:
+		return fmt.Sprintf("int%d", t.Width)
+	default:
+		return fmt.Sprintf("uint%d", t.Width)
+	}
+}
+
+// Min returns the smallest value of t, or nil if t is unbounded
+func (t IntType) Min() *big.Int {
+	switch {
+	case t.Width == 0:
+		return nil
+	case t.Signed:
+		return new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), uint(t.Width-1)))
+	default:
+		return new(big.Int)
+	}
+}
+
+// Max returns the largest value of t, or nil if t is unbounded
+func (t IntType) Max() *big.Int {
+	if t.Width == 0 {
+		return nil
+	}
+	n := uint(t.Width)
+	if t.Signed {
+		n--
+	}
+	return new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), n), big.NewInt(1))
+}
+
+// Contains reports whether x fits in t
+func (t IntType) Contains(x *big.Int) bool {
+	return t.Width == 0 || (x.Cmp(t.Min()) >= 0 && x.Cmp(t.Max()) <= 0)
+}
+
+// check returns x, or an *OverflowError for op if it does not fit in t
+func (t IntType) check(op string, x *big.Int) (*big.Int, error) {
+	if !t.Contains(x) {
+		return nil, &OverflowError{Op: op, Type: t}
+	}
+	return x, nil
+}
+
+// checkArgs returns an *OverflowError if any operand does not fit in t
+func (t IntType) checkArgs(args ...*big.Int) error {
+	for _, x := range args {
+		if !t.Contains(x) {
+			return &OverflowError{Op: fmt.Sprintf("operand %s", x), Type: t}
+		}
+	}
+	return nil
+}
+
+// Add returns a + b, or an *OverflowError if it does not fit in t
+func (t IntType) Add(a, b *big.Int) (*big.Int, error) {
+	if err := t.checkArgs(a, b); err != nil {
+		return nil, err
+	}
+	return t.check(fmt.Sprintf("%s + %s", a, b), new(big.Int).Add(a, b))
+}
+
+// Subtract returns a - b, or an *OverflowError if it does not fit in t
+func (t IntType) Subtract(a, b *big.Int) (*big.Int, error) {
+	if err := t.checkArgs(a, b); err != nil {
+		return nil, err
+	}
+	return t.check(fmt.Sprintf("%s - %s", a, b), new(big.Int).Sub(a, b))
+}
+
+// Multiply returns a × b, or an *OverflowError if it does not fit in t
+func (t IntType) Multiply(a, b *big.Int) (*big.Int, error) {
+	if err := t.checkArgs(a, b); err != nil {
+		return nil, err
+	}
+	return t.check(fmt.Sprintf("%s * %s", a, b), new(big.Int).Mul(a, b))
+}
+
+// Divide returns a / b truncated toward zero, as Go's integer division
+// If b is 0, returns ErrDivisionByZero; the minimum signed value divided by -1 overflows
+func (t IntType) Divide(a, b *big.Int) (*big.Int, error) {
+	if err := t.checkArgs(a, b); err != nil {
+		return nil, err
+	}
+	if b.Sign() == 0 {
+		return nil, ErrDivisionByZero
+	}
+	return t.check(fmt.Sprintf("%s / %s", a, b), new(big.Int).Quo(a, b))
+}
+
+// Remainder returns a % b with the sign of a, as Go's % operator
+// If b is 0, returns ErrDivisionByZero
+func (t IntType) Remainder(a, b *big.Int) (*big.Int, error) {
+	if err := t.checkArgs(a, b); err != nil {
+		return nil, err
+	}
+	if b.Sign() == 0 {
+		return nil, ErrDivisionByZero
+	}
+	return new(big.Int).Rem(a, b), nil
+}
+
+// Neg returns -x; negating the minimum signed value or a non-zero unsigned value overflows
+func (t IntType) Neg(x *big.Int) (*big.Int, error) {
+	if err := t.checkArgs(x); err != nil {
+		return nil, err
+	}
+	return t.check(fmt.Sprintf("-(%s)", x), new(big.Int).Neg(x))
+}
+
+// And returns the bitwise AND of a and b
+func (t IntType) And(a, b *big.Int) (*big.Int, error) {
+	if err := t.checkArgs(a, b); err != nil {
+		return nil, err
+	}
+	return new(big.Int).And(a, b), nil
+}
+
+// Or returns the bitwise OR of a and b
+func (t IntType) Or(a, b *big.Int) (*big.Int, error) {
+	if err := t.checkArgs(a, b); err != nil {
+		return nil, err
+	}
+	return new(big.Int).Or(a, b), nil
+}
+
+// Xor returns the bitwise exclusive OR of a and b
+func (t IntType) Xor(a, b *big.Int) (*big.Int, error) {
+	if err := t.checkArgs(a, b); err != nil {
+		return nil, err
+	}
+	return new(big.Int).Xor(a, b), nil
+}
+
+// Not returns the bitwise complement of x: -x-1 for signed types and Max - x for unsigned ones
+func (t IntType) Not(x *big.Int) (*big.Int, error) {
+	if err := t.checkArgs(x); err != nil {
+		return nil, err
+	}
+	if !t.Signed {
+		return new(big.Int).Sub(t.Max(), x), nil
+	}
+	return new(big.Int).Not(x), nil
+}
+
+// Shl returns x << n, or an *OverflowError if bits are shifted out of t
+func (t IntType) Shl(x *big.Int, n uint) (*big.Int, error) {
+	if err := t.checkArgs(x); err != nil {
+		return nil, err
+	}
+	return t.check(fmt.Sprintf("%s << %d", x, n), new(big.Int).Lsh(x, n))
+}
+
+// Shr returns x >> n, an arithmetic shift that keeps the sign of signed values
+func (t IntType) Shr(x *big.Int, n uint) (*big.Int, error) {
+	if err := t.checkArgs(x); err != nil {
+		return nil, err
+	}
+	return new(big.Int).Rsh(x, n), nil
+}
+
+// Wrap reduces x modulo 2^Width into t, the silent wrapping of Go's fixed-size integers,
+// e.g. Int8.Wrap(200) is -56
+func (t IntType) Wrap(x *big.Int) *big.Int {
+	if t.Width == 0 {
+		return new(big.Int).Set(x)
+	}
+	modulus := new(big.Int).Lsh(big.NewInt(1), uint(t.Width))
+	v := new(big.Int).Mod(x, modulus)
+	if t.Signed && v.Cmp(t.Max()) > 0 {
+		v.Sub(v, modulus)
+	}
+	return v
+}
+
+// Bits returns the two's complement bit pattern of x in t, padded to the full width,
+// e.g. Int8.Bits(-2) is "11111110"
+// Returns an *OverflowError if x does not fit in t; an unbounded type has no fixed pattern
+func (t IntType) Bits(x *big.Int) (string, error) {
+	if t.Width == 0 {
+		return "", fmt.Errorf("%s has no fixed width", t)
+	}
+	if err := t.checkArgs(x); err != nil {
+		return "", err
+	}
+	// Mod is Euclidean, so negative values map to their two's complement pattern
+	pattern := new(big.Int).Mod(x, new(big.Int).Lsh(big.NewInt(1), uint(t.Width)))
+	text := pattern.Text(2)
+	return strings.Repeat("0", int(t.Width)-len(text)) + text, nil
+}
+
+// FromBits interprets the low Width bits of pattern as a value of t,
+// e.g. Int8.FromBits(0xFF) is -1 and Uint8.FromBits(0xFF) is 255
+func (t IntType) FromBits(pattern uint64) *big.Int {
+	return t.Wrap(new(big.Int).SetUint64(pattern))
+}
+
+// AddInt64 returns a + b, or an *OverflowError instead of wrapping
+func AddInt64(a, b int64) (int64, error) {
+	sum := a + b
+	if (b > 0 && sum < a) || (b < 0 && sum > a) {
+		return 0, &OverflowError{Op: fmt.Sprintf("%d + %d", a, b), Type: Int64}
+	}
+	return sum, nil
+}
+
+// SubtractInt64 returns a - b, or an *OverflowError instead of wrapping
+func SubtractInt64(a, b int64) (int64, error) {
+	diff := a - b
+	if (b > 0 && diff > a) || (b < 0 && diff < a) {
+		return 0, &OverflowError{Op: fmt.Sprintf("%d - %d", a, b), Type: Int64}
+	}
+	return diff, nil
+}
+
+// MultiplyInt64 returns a × b, or an *OverflowError instead of wrapping
+func MultiplyInt64(a, b int64) (int64, error) {
+	product := a * b
+	// product/a wraps back to b for -1 × math.MinInt64, so that case is checked separately
+	if a != 0 && (product/a != b || (a == -1 && b == math.MinInt64)) {
+		return 0, &OverflowError{Op: fmt.Sprintf("%d * %d", a, b), Type: Int64}
+	}
+	return product, nil
+}
+
+// DivideInt64 returns a / b truncated toward zero
+// If b is 0, returns ErrDivisionByZero; math.MinInt64 / -1 overflows
+func DivideInt64(a, b int64) (int64, error) {
+	if b == 0 {
+		return 0, ErrDivisionByZero
+	}
+	if a == math.MinInt64 && b == -1 {
+		return 0, &OverflowError{Op: fmt.Sprintf("%d / %d", a, b), Type: Int64}
+	}
+	return a / b, nil
+}
+
+// AddUint64 returns a + b, or an *OverflowError instead of wrapping
+func AddUint64(a, b uint64) (uint64, error) {
+	sum, carry := bits.Add64(a, b, 0)
+	if carry != 0 {
+		return 0, &OverflowError{Op: fmt.Sprintf("%d + %d", a, b), Type: Uint64}
+	}
+	return sum, nil
+}
+
+// SubtractUint64 returns a - b, or an *OverflowError if b is greater than a
+func SubtractUint64(a, b uint64) (uint64, error) {
+	diff, borrow := bits.Sub64(a, b, 0)
+	if borrow != 0 {
+		return 0, &OverflowError{Op: fmt.Sprintf("%d - %d", a, b), Type: Uint64}
+	}
+	return diff, nil
+}
+
+// MultiplyUint64 returns a × b, or an *OverflowError instead of wrapping
+func MultiplyUint64(a, b uint64) (uint64, error) {
+	hi, lo := bits.Mul64(a, b)
+	if hi != 0 {
+		return 0, &OverflowError{Op: fmt.Sprintf("%d * %d", a, b), Type: Uint64}
+	}
+	return lo, nil
+}
+
+// DivideUint64 returns a / b
+// If b is 0, returns ErrDivisionByZero
+func DivideUint64(a, b uint64) (uint64, error) {
+	if b == 0 {
+		return 0, ErrDivisionByZero
+	}
+	return a / b, nil
+}
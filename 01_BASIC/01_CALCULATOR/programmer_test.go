@@ -0,0 +1,336 @@
+package calculator
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestParseInt(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"255", "255"},
+		{"0xFF", "255"},
+		{"-0xff", "-255"},
+		{"0o17", "15"},
+		{"0b1010", "10"},
+		{"1_000_000", "1000000"},
+		{"0x1_0000_0000_0000_0000", "18446744073709551616"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseInt(tt.input)
+			if err != nil {
+				t.Fatalf("ParseInt(%q) unexpected error: %v", tt.input, err)
+			}
+			if got.String() != tt.expected {
+				t.Errorf("ParseInt(%q) = %s; want %s", tt.input, got, tt.expected)
+			}
+		})
+	}
+
+	for _, invalid := range []string{"", "0xG", "0b102", "1.5", "--1"} {
+		if _, err := ParseInt(invalid); err == nil {
+			t.Errorf("ParseInt(%q) expected error", invalid)
+		}
+	}
+}
+
+func TestIntBases(t *testing.T) {
+	tests := []struct {
+		value    int64
+		base     int
+		expected string
+	}{
+		{255, 16, "0xff"},
+		{-255, 16, "-0xff"},
+		{10, 2, "0b1010"},
+		{15, 8, "0o17"},
+		{1295, 36, "zz"},
+		{42, 10, "42"},
+		{0, 2, "0b0"},
+		{3843, 62, "ZZ"},
+	}
+
+	for _, tt := range tests {
+		got, err := FormatInt(big.NewInt(tt.value), tt.base)
+		if err != nil || got != tt.expected {
+			t.Errorf("FormatInt(%d, %d) = %q, %v; want %q", tt.value, tt.base, got, err, tt.expected)
+		}
+		if tt.base == 2 || tt.base == 8 || tt.base == 16 {
+			if back, err := ParseInt(got); err != nil || back.Int64() != tt.value {
+				t.Errorf("ParseInt(%q) = %v, %v; want %d", got, back, err, tt.value)
+			}
+		} else if back, err := ParseIntBase(got, tt.base); err != nil || back.Int64() != tt.value {
+			t.Errorf("ParseIntBase(%q, %d) = %v, %v; want %d", got, tt.base, back, err, tt.value)
+		}
+	}
+	for _, base := range []int{0, 1, 63} {
+		if _, err := FormatInt(big.NewInt(7), base); err == nil {
+			t.Errorf("FormatInt(7, %d) expected error", base)
+		}
+	}
+
+	if got, err := ParseIntBase("zz", 36); err != nil || got.Int64() != 1295 {
+		t.Errorf("ParseIntBase(zz, 36) = %v, %v; want 1295", got, err)
+	}
+	if _, err := ParseIntBase("12", 1); err == nil {
+		t.Errorf("ParseIntBase with base 1 expected error")
+	}
+	if _, err := ParseIntBase("19", 8); err == nil {
+		t.Errorf("ParseIntBase(19, 8) expected error")
+	}
+}
+
+func TestIntTypeRange(t *testing.T) {
+	tests := []struct {
+		typ      IntType
+		name     string
+		min, max string
+	}{
+		{Int8, "int8", "-128", "127"},
+		{Uint8, "uint8", "0", "255"},
+		{Int16, "int16", "-32768", "32767"},
+		{Uint32, "uint32", "0", "4294967295"},
+		{Int64, "int64", "-9223372036854775808", "9223372036854775807"},
+		{Uint64, "uint64", "0", "18446744073709551615"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.typ.String() != tt.name || tt.typ.Min().String() != tt.min || tt.typ.Max().String() != tt.max {
+				t.Errorf("%s range = [%s, %s]; want %s [%s, %s]", tt.typ, tt.typ.Min(), tt.typ.Max(), tt.name, tt.min, tt.max)
+			}
+		})
+	}
+	if BigInt.Min() != nil || BigInt.Max() != nil || !BigInt.Contains(new(big.Int).Lsh(big.NewInt(1), 200)) {
+		t.Errorf("BigInt should be unbounded")
+	}
+}
+
+func TestIntTypeOperations(t *testing.T) {
+	n := big.NewInt
+	tests := []struct {
+		name     string
+		fn       func() (*big.Int, error)
+		expected int64
+	}{
+		{"add", func() (*big.Int, error) { return Int8.Add(n(100), n(27)) }, 127},
+		{"subtract", func() (*big.Int, error) { return Int8.Subtract(n(-100), n(28)) }, -128},
+		{"multiply", func() (*big.Int, error) { return Uint16.Multiply(n(255), n(257)) }, 65535},
+		{"divide truncates", func() (*big.Int, error) { return Int32.Divide(n(-7), n(2)) }, -3},
+		{"remainder", func() (*big.Int, error) { return Int32.Remainder(n(-7), n(2)) }, -1},
+		{"neg", func() (*big.Int, error) { return Int8.Neg(n(127)) }, -127},
+		{"and", func() (*big.Int, error) { return Uint8.And(n(0b1100), n(0b1010)) }, 0b1000},
+		{"or", func() (*big.Int, error) { return Uint8.Or(n(0b1100), n(0b1010)) }, 0b1110},
+		{"xor", func() (*big.Int, error) { return Uint8.Xor(n(0b1100), n(0b1010)) }, 0b0110},
+		{"xor negative", func() (*big.Int, error) { return Int8.Xor(n(-1), n(0x0F)) }, -16},
+		{"not signed", func() (*big.Int, error) { return Int8.Not(n(0)) }, -1},
+		{"not unsigned", func() (*big.Int, error) { return Uint8.Not(n(0)) }, 255},
+		{"not unsigned 16", func() (*big.Int, error) { return Uint16.Not(n(0xFF)) }, 0xFF00},
+		{"shl", func() (*big.Int, error) { return Uint8.Shl(n(1), 7) }, 128},
+		{"shl negative", func() (*big.Int, error) { return Int8.Shl(n(-1), 7) }, -128},
+		{"shr arithmetic", func() (*big.Int, error) { return Int8.Shr(n(-128), 3) }, -16},
+		{"shr logical", func() (*big.Int, error) { return Uint8.Shr(n(128), 3) }, 16},
+		{"shr past width", func() (*big.Int, error) { return Int32.Shr(n(-5), 40) }, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Int64() != tt.expected {
+				t.Errorf("got %s; want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIntTypeOverflow(t *testing.T) {
+	n := big.NewInt
+	tests := []struct {
+		name string
+		fn   func() (*big.Int, error)
+		msg  string
+	}{
+		{"add", func() (*big.Int, error) { return Int8.Add(n(127), n(1)) }, "127 + 1 overflows int8"},
+		{"subtract unsigned", func() (*big.Int, error) { return Uint32.Subtract(n(0), n(1)) }, "0 - 1 overflows uint32"},
+		{"multiply", func() (*big.Int, error) { return Int16.Multiply(n(256), n(128)) }, "256 * 128 overflows int16"},
+		{"divide", func() (*big.Int, error) { return Int8.Divide(n(-128), n(-1)) }, "-128 / -1 overflows int8"},
+		{"neg", func() (*big.Int, error) { return Int8.Neg(n(-128)) }, "-(-128) overflows int8"},
+		{"neg unsigned", func() (*big.Int, error) { return Uint8.Neg(n(1)) }, "-(1) overflows uint8"},
+		{"shl", func() (*big.Int, error) { return Uint8.Shl(n(1), 8) }, "1 << 8 overflows uint8"},
+		{"shl sign bit", func() (*big.Int, error) { return Int8.Shl(n(1), 7) }, "1 << 7 overflows int8"},
+		{"operand", func() (*big.Int, error) { return Uint8.And(n(256), n(1)) }, "operand 256 overflows uint8"},
+		{"negative operand", func() (*big.Int, error) { return Uint64.Add(n(-1), n(1)) }, "operand -1 overflows uint64"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.fn()
+			var overflow *OverflowError
+			if !errors.Is(err, ErrOverflow) || !errors.As(err, &overflow) {
+				t.Fatalf("error = %v; want *OverflowError", err)
+			}
+			if err.Error() != tt.msg {
+				t.Errorf("error = %q; want %q", err, tt.msg)
+			}
+		})
+	}
+
+	if _, err := Int64.Divide(n(1), n(0)); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("Divide by zero error = %v; want ErrDivisionByZero", err)
+	}
+	if _, err := Uint8.Remainder(n(1), n(0)); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("Remainder by zero error = %v; want ErrDivisionByZero", err)
+	}
+	huge := new(big.Int).Lsh(n(1), 100)
+	if got, err := BigInt.Multiply(huge, huge); err != nil || got.BitLen() != 201 {
+		t.Errorf("BigInt.Multiply(2^100, 2^100) = %v, %v; want 2^200", got, err)
+	}
+}
+
+func TestTwosComplement(t *testing.T) {
+	tests := []struct {
+		typ      IntType
+		value    int64
+		expected string
+	}{
+		{Int8, -1, "11111111"},
+		{Int8, -2, "11111110"},
+		{Int8, -128, "10000000"},
+		{Int8, 5, "00000101"},
+		{Uint8, 255, "11111111"},
+		{Int16, -256, "1111111100000000"},
+		{Int32, math.MinInt32, "10000000000000000000000000000000"},
+		{Int64, -1, "1111111111111111111111111111111111111111111111111111111111111111"},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.typ.Bits(big.NewInt(tt.value))
+		if err != nil {
+			t.Fatalf("%s.Bits(%d) unexpected error: %v", tt.typ, tt.value, err)
+		}
+		if got != tt.expected {
+			t.Errorf("%s.Bits(%d) = %s; want %s", tt.typ, tt.value, got, tt.expected)
+		}
+	}
+
+	if _, err := Int8.Bits(big.NewInt(128)); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Int8.Bits(128) error = %v; want ErrOverflow", err)
+	}
+	if _, err := BigInt.Bits(big.NewInt(1)); err == nil {
+		t.Errorf("BigInt.Bits expected error")
+	}
+
+	views := []struct {
+		typ      IntType
+		pattern  uint64
+		expected string
+	}{
+		{Int8, 0xFF, "-1"},
+		{Uint8, 0xFF, "255"},
+		{Int16, 0x8000, "-32768"},
+		{Int8, 0x1FF, "-1"},
+		{Int64, math.MaxUint64, "-1"},
+		{Uint64, math.MaxUint64, "18446744073709551615"},
+	}
+	for _, v := range views {
+		if got := v.typ.FromBits(v.pattern); got.String() != v.expected {
+			t.Errorf("%s.FromBits(%#x) = %s; want %s", v.typ, v.pattern, got, v.expected)
+		}
+	}
+
+	if got := Int8.Wrap(big.NewInt(200)); got.Int64() != -56 {
+		t.Errorf("Int8.Wrap(200) = %s; want -56", got)
+	}
+	if got := Uint16.Wrap(big.NewInt(-1)); got.Int64() != 65535 {
+		t.Errorf("Uint16.Wrap(-1) = %s; want 65535", got)
+	}
+}
+
+func TestCheckedInt64(t *testing.T) {
+	tests := []struct {
+		name     string
+		fn       func(a, b int64) (int64, error)
+		a, b     int64
+		expected int64
+		overflow bool
+	}{
+		{"add", AddInt64, 1, 2, 3, false},
+		{"add overflow", AddInt64, math.MaxInt64, 1, 0, true},
+		{"add negative overflow", AddInt64, math.MinInt64, -1, 0, true},
+		{"subtract", SubtractInt64, -5, 3, -8, false},
+		{"subtract overflow", SubtractInt64, math.MinInt64, 1, 0, true},
+		{"subtract negative overflow", SubtractInt64, 0, math.MinInt64, 0, true},
+		{"multiply", MultiplyInt64, -4, 5, -20, false},
+		{"multiply zero", MultiplyInt64, 0, math.MinInt64, 0, false},
+		{"multiply overflow", MultiplyInt64, math.MaxInt64/2 + 1, 2, 0, true},
+		{"multiply min by -1", MultiplyInt64, -1, math.MinInt64, 0, true},
+		{"multiply -1 by min", MultiplyInt64, math.MinInt64, -1, 0, true},
+		{"divide", DivideInt64, 7, -2, -3, false},
+		{"divide overflow", DivideInt64, math.MinInt64, -1, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn(tt.a, tt.b)
+			if tt.overflow {
+				if !errors.Is(err, ErrOverflow) {
+					t.Errorf("(%d, %d) error = %v; want ErrOverflow", tt.a, tt.b, err)
+				}
+				return
+			}
+			if err != nil || got != tt.expected {
+				t.Errorf("(%d, %d) = %d, %v; want %d", tt.a, tt.b, got, err, tt.expected)
+			}
+		})
+	}
+
+	if _, err := DivideInt64(1, 0); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("DivideInt64(1, 0) error = %v; want ErrDivisionByZero", err)
+	}
+}
+
+func TestCheckedUint64(t *testing.T) {
+	tests := []struct {
+		name     string
+		fn       func(a, b uint64) (uint64, error)
+		a, b     uint64
+		expected uint64
+		overflow bool
+	}{
+		{"add", AddUint64, 1, 2, 3, false},
+		{"add overflow", AddUint64, math.MaxUint64, 1, 0, true},
+		{"subtract", SubtractUint64, 5, 3, 2, false},
+		{"subtract overflow", SubtractUint64, 3, 5, 0, true},
+		{"multiply", MultiplyUint64, 1 << 31, 1 << 32, 1 << 63, false},
+		{"multiply overflow", MultiplyUint64, 1 << 32, 1 << 32, 0, true},
+		{"divide", DivideUint64, 7, 2, 3, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn(tt.a, tt.b)
+			if tt.overflow {
+				if !errors.Is(err, ErrOverflow) {
+					t.Errorf("(%d, %d) error = %v; want ErrOverflow", tt.a, tt.b, err)
+				}
+				return
+			}
+			if err != nil || got != tt.expected {
+				t.Errorf("(%d, %d) = %d, %v; want %d", tt.a, tt.b, got, err, tt.expected)
+			}
+		})
+	}
+
+	if _, err := DivideUint64(1, 0); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("DivideUint64(1, 0) error = %v; want ErrDivisionByZero", err)
+	}
+}
@@ -0,0 +1,203 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// ErrEmptyInput is returned by statistics that are undefined for an empty data set
+var ErrEmptyInput = errors.New("empty input")
+
+// RunningStats accumulates the count, mean and variance of a stream of values
+// with Welford's algorithm, which avoids the cancellation of the sum-of-squares formula
+// The zero value is ready to use
+type RunningStats struct {
+	n        int
+	mean, m2 float64
+	min, max float64
+}
+
+// Push adds x to the statistics
+func (s *RunningStats) Push(x float64) {
+	s.n++
+	if s.n == 1 {
+		s.min, s.max = x, x
+	}
+	s.min, s.max = math.Min(s.min, x), math.Max(s.max, x)
+	delta := x - s.mean
+	s.mean += delta / float64(s.n)
+	s.m2 += delta * (x - s.mean)
+}
+
+// Count returns the number of values pushed
+func (s *RunningStats) Count() int {
+	return s.n
+}
+
+// Mean returns the mean of the values pushed
+// If there are none, returns ErrEmptyInput
+func (s *RunningStats) Mean() (float64, error) {
+	if s.n == 0 {
+		return 0, ErrEmptyInput
+	}
+	return s.mean, nil
+}
+
+// Min returns the smallest value pushed
+// If there are none, returns ErrEmptyInput
+func (s *RunningStats) Min() (float64, error) {
+	if s.n == 0 {
+		return 0, ErrEmptyInput
+	}
+	return s.min, nil
+}
+
+// Max returns the largest value pushed
+// If there are none, returns ErrEmptyInput
+func (s *RunningStats) Max() (float64, error) {
+	if s.n == 0 {
+		return 0, ErrEmptyInput
+	}
+	return s.max, nil
+}
+
+// Variance returns the sample variance of the values pushed, dividing by n - 1
+// If there are none, returns ErrEmptyInput; a single value has no sample variance
+func (s *RunningStats) Variance() (float64, error) {
+	if s.n == 0 {
+		return 0, ErrEmptyInput
+	}
+	if s.n == 1 {
+		return 0, errors.New("sample variance needs at least 2 values")
+	}
+	return s.m2 / float64(s.n-1), nil
+}
+
+// PopulationVariance returns the population variance of the values pushed, dividing by n
+// If there are none, returns ErrEmptyInput
+func (s *RunningStats) PopulationVariance() (float64, error) {
+	if s.n == 0 {
+		return 0, ErrEmptyInput
+	}
+	return s.m2 / float64(s.n), nil
+}
+
+func runningStats(xs []float64) *RunningStats {
+	s := &RunningStats{}
+	for _, x := range xs {
+		s.Push(x)
+	}
+	return s
+}
+
+// Mean returns the arithmetic mean of xs
+// If xs is empty, returns ErrEmptyInput
+func Mean(xs []float64) (float64, error) {
+	return runningStats(xs).Mean()
+}
+
+// Median returns the middle value of xs, or the mean of the two middle values
+// If xs is empty, returns ErrEmptyInput
+func Median(xs []float64) (float64, error) {
+	return Percentile(xs, 50)
+}
+
+// Mode returns the most frequent values of xs in ascending order
+// If xs is empty, returns ErrEmptyInput
+func Mode(xs []float64) ([]float64, error) {
+	if len(xs) == 0 {
+		return nil, ErrEmptyInput
+	}
+	counts := make(map[float64]int, len(xs))
+	most := 0
+	for _, x := range xs {
+		counts[x]++
+		most = max(most, counts[x])
+	}
+	var modes []float64
+	for x, c := range counts {
+		if c == most {
+			modes = append(modes, x)
+		}
+	}
+	slices.Sort(modes)
+	return modes, nil
+}
+
+// Variance returns the sample variance of xs, dividing by n - 1
+// If xs is empty, returns ErrEmptyInput; a single value has no sample variance
+func Variance(xs []float64) (float64, error) {
+	return runningStats(xs).Variance()
+}
+
+// PopulationVariance returns the population variance of xs, dividing by n
+// If xs is empty, returns ErrEmptyInput
+func PopulationVariance(xs []float64) (float64, error) {
+	return runningStats(xs).PopulationVariance()
+}
+
+// StdDev returns the sample standard deviation of xs
+// If xs is empty, returns ErrEmptyInput
+func StdDev(xs []float64) (float64, error) {
+	v, err := Variance(xs)
+	return math.Sqrt(v), err
+}
+
+// PopulationStdDev returns the population standard deviation of xs
+// If xs is empty, returns ErrEmptyInput
+func PopulationStdDev(xs []float64) (float64, error) {
+	v, err := PopulationVariance(xs)
+	return math.Sqrt(v), err
+}
+
+// Percentile returns the p-th percentile of xs for p in [0, 100],
+// interpolating linearly between the closest ranks as spreadsheets' PERCENTILE.INC does
+// If xs is empty, returns ErrEmptyInput
+func Percentile(xs []float64, p float64) (float64, error) {
+	if len(xs) == 0 {
+		return 0, ErrEmptyInput
+	}
+	if p < 0 || p > 100 || math.IsNaN(p) {
+		return 0, fmt.Errorf("percentile must be between 0 and 100, got %g", p)
+	}
+	sorted := slices.Clone(xs)
+	slices.Sort(sorted)
+
+	rank := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	if lo == len(sorted)-1 {
+		return sorted[lo], nil
+	}
+	frac := rank - float64(lo)
+	return sorted[lo] + frac*(sorted[lo+1]-sorted[lo]), nil
+}
+
+// Correlation returns the Pearson correlation coefficient of xs and ys
+// If they are empty, returns ErrEmptyInput; if either is constant, the coefficient
+// is undefined and the error wraps ErrDivisionByZero
+func Correlation(xs, ys []float64) (float64, error) {
+	if len(xs) != len(ys) {
+		return 0, fmt.Errorf("correlation needs equal lengths, got %d and %d", len(xs), len(ys))
+	}
+	if len(xs) == 0 {
+		return 0, ErrEmptyInput
+	}
+
+	// Welford's update extended to the co-moment of both series
+	var meanX, meanY, m2X, m2Y, cov float64
+	for i := range xs {
+		n := float64(i + 1)
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		meanX += dx / n
+		meanY += dy / n
+		m2X += dx * (xs[i] - meanX)
+		m2Y += dy * (ys[i] - meanY)
+		cov += dx * (ys[i] - meanY)
+	}
+	if m2X == 0 || m2Y == 0 {
+		return 0, fmt.Errorf("correlation of a constant series: %w", ErrDivisionByZero)
+	}
+	return cov / math.Sqrt(m2X*m2Y), nil
+}
@@ -0,0 +1,157 @@
+package calculator
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestDescriptiveStatistics(t *testing.T) {
+	data := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	tests := []struct {
+		name     string
+		fn       func([]float64) (float64, error)
+		expected float64
+	}{
+		{"mean", Mean, 5},
+		{"median", Median, 4.5},
+		{"variance", Variance, 32.0 / 7},
+		{"population variance", PopulationVariance, 4},
+		{"stddev", StdDev, math.Sqrt(32.0 / 7)},
+		{"population stddev", PopulationStdDev, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn(data)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if math.Abs(got-tt.expected) > 1e-12 {
+				t.Errorf("got %v; want %v", got, tt.expected)
+			}
+		})
+		t.Run(tt.name+" empty", func(t *testing.T) {
+			if _, err := tt.fn(nil); !errors.Is(err, ErrEmptyInput) {
+				t.Errorf("error = %v; want ErrEmptyInput", err)
+			}
+		})
+	}
+}
+
+func TestVarianceStability(t *testing.T) {
+	// The sum-of-squares formula loses every digit here
+	data := []float64{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16}
+	v, err := Variance(data)
+	if err != nil || v != 30 {
+		t.Errorf("Variance = %v, %v; want 30", v, err)
+	}
+
+	if _, err := Variance([]float64{1}); err == nil || errors.Is(err, ErrEmptyInput) {
+		t.Errorf("Variance of one value error = %v; want a sample size error", err)
+	}
+	if v, err := PopulationVariance([]float64{1}); err != nil || v != 0 {
+		t.Errorf("PopulationVariance of one value = %v, %v; want 0", v, err)
+	}
+}
+
+func TestRunningStats(t *testing.T) {
+	var s RunningStats
+	if _, err := s.Mean(); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("Mean of zero value error = %v; want ErrEmptyInput", err)
+	}
+	for _, x := range []float64{3, -1, 4, 1, 5} {
+		s.Push(x)
+	}
+	mean, _ := s.Mean()
+	variance, _ := s.Variance()
+	lo, _ := s.Min()
+	hi, _ := s.Max()
+	if s.Count() != 5 || mean != 2.4 || math.Abs(variance-5.8) > 1e-12 || lo != -1 || hi != 5 {
+		t.Errorf("count %d, mean %v, variance %v, min %v, max %v", s.Count(), mean, variance, lo, hi)
+	}
+}
+
+func TestMode(t *testing.T) {
+	tests := []struct {
+		input    []float64
+		expected []float64
+	}{
+		{[]float64{1, 2, 2, 3}, []float64{2}},
+		{[]float64{3, 1, 3, 1, 2}, []float64{1, 3}},
+		{[]float64{7}, []float64{7}},
+	}
+
+	for _, tt := range tests {
+		got, err := Mode(tt.input)
+		if err != nil || !slices.Equal(got, tt.expected) {
+			t.Errorf("Mode(%v) = %v, %v; want %v", tt.input, got, err, tt.expected)
+		}
+	}
+	if _, err := Mode(nil); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("Mode(nil) error = %v; want ErrEmptyInput", err)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	data := []float64{4, 1, 3, 2}
+	tests := []struct {
+		p        float64
+		expected float64
+	}{
+		{0, 1},
+		{25, 1.75},
+		{50, 2.5},
+		{90, 3.7},
+		{100, 4},
+	}
+
+	for _, tt := range tests {
+		got, err := Percentile(data, tt.p)
+		if err != nil || math.Abs(got-tt.expected) > 1e-12 {
+			t.Errorf("Percentile(%v, %v) = %v, %v; want %v", data, tt.p, got, err, tt.expected)
+		}
+	}
+	if !slices.Equal(data, []float64{4, 1, 3, 2}) {
+		t.Errorf("Percentile modified its input: %v", data)
+	}
+	if _, err := Percentile(data, 101); err == nil {
+		t.Errorf("Percentile(101) expected error")
+	}
+	if _, err := Percentile(nil, 50); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("Percentile(nil) error = %v; want ErrEmptyInput", err)
+	}
+}
+
+func TestCorrelation(t *testing.T) {
+	tests := []struct {
+		name     string
+		xs, ys   []float64
+		expected float64
+	}{
+		{"positive", []float64{1, 2, 3, 4, 5}, []float64{2, 4, 5, 4, 5}, 0.7745966692414834},
+		{"linear", []float64{1, 2, 3}, []float64{10, 20, 30}, 1},
+		{"inverse", []float64{1, 2, 3}, []float64{3, 2, 1}, -1},
+		{"offset", []float64{1e9 + 1, 1e9 + 2, 1e9 + 3}, []float64{1, 2, 3}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Correlation(tt.xs, tt.ys)
+			if err != nil || math.Abs(got-tt.expected) > 1e-12 {
+				t.Errorf("Correlation = %v, %v; want %v", got, err, tt.expected)
+			}
+		})
+	}
+
+	if _, err := Correlation([]float64{1, 1}, []float64{1, 2}); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("Correlation of a constant series error = %v; want ErrDivisionByZero", err)
+	}
+	if _, err := Correlation(nil, nil); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("Correlation(nil, nil) error = %v; want ErrEmptyInput", err)
+	}
+	if _, err := Correlation([]float64{1}, []float64{1, 2}); err == nil {
+		t.Errorf("Correlation with different lengths expected error")
+	}
+}
@@ -0,0 +1,373 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Derive parses expr and returns its simplified derivative with respect to variable,
+// e.g. Derive("x^3 + 2*x", "x") is 3 * x^2 + 2
+func Derive(expr, variable string) (Node, error) {
+	n, err := Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	d, err := Differentiate(n, variable)
+	if err != nil {
+		return nil, err
+	}
+	return Simplify(d), nil
+}
+
+// Differentiate returns the derivative of n with respect to variable without simplifying it
+// Supports + - * / ^ and the built-ins sqrt, sin, cos, log, exp, abs and pow
+func Differentiate(n Node, variable string) (Node, error) {
+	if !dependsOn(n, variable) {
+		return num(0), nil
+	}
+
+	switch n := n.(type) {
+	case *Ident:
+		return num(1), nil
+	case *Unary:
+		dx, err := Differentiate(n.X, variable)
+		if err != nil {
+			return nil, err
+		}
+		return &Unary{Op: n.Op, X: dx}, nil
+	case *Binary:
+		dx, err := Differentiate(n.X, variable)
+		if err != nil {
+			return nil, err
+		}
+		dy, err := Differentiate(n.Y, variable)
+		if err != nil {
+			return nil, err
+		}
+		return deriveBinary(n, dx, dy, variable), nil
+	case *Call:
+		return deriveCall(n, variable)
+	}
+	return nil, fmt.Errorf("cannot differentiate %s", n)
+}
+
+func deriveBinary(n *Binary, dx, dy Node, variable string) Node {
+	x, y := n.X, n.Y
+	switch n.Op {
+	case '+', '-':
+		return bin(n.Op, dx, dy)
+	case '*':
+		// (xy)' = x'y + xy'
+		return bin('+', bin('*', dx, y), bin('*', x, dy))
+	case '/':
+		// (x/y)' = (x'y - xy') / y^2
+		return bin('/', bin('-', bin('*', dx, y), bin('*', x, dy)), bin('^', y, num(2)))
+	}
+
+	// Power rule when the exponent is constant, exponential rule when the base is
+	switch {
+	case !dependsOn(y, variable):
+		return bin('*', bin('*', y, bin('^', x, bin('-', y, num(1)))), dx)
+	case !dependsOn(x, variable):
+		return bin('*', bin('*', n, call("log", x)), dy)
+	default:
+		// (x^y)' = x^y (y' log x + y x' / x)
+		return bin('*', n, bin('+', bin('*', dy, call("log", x)), bin('/', bin('*', y, dx), x)))
+	}
+}
+
+func deriveCall(n *Call, variable string) (Node, error) {
+	if n.Name == "pow" && len(n.Args) == 2 {
+		return Differentiate(bin('^', n.Args[0], n.Args[1]), variable)
+	}
+	if len(n.Args) != 1 {
+		return nil, fmt.Errorf("cannot differentiate %s", n)
+	}
+
+	u := n.Args[0]
+	var outer Node
+	switch n.Name {
+	case "sin":
+		outer = call("cos", u)
+	case "cos":
+		outer = &Unary{Op: '-', X: call("sin", u)}
+	case "exp":
+		outer = n
+	case "log":
+		outer = bin('/', num(1), u)
+	case "sqrt":
+		outer = bin('/', num(1), bin('*', num(2), n))
+	case "abs":
+		outer = bin('/', u, n)
+	default:
+		return nil, fmt.Errorf("cannot differentiate %s", n)
+	}
+
+	// Chain rule: f(u)' = f'(u) u'
+	du, err := Differentiate(u, variable)
+	if err != nil {
+		return nil, err
+	}
+	return bin('*', outer, du), nil
+}
+
+// dependsOn reports whether variable appears anywhere in n
+func dependsOn(n Node, variable string) bool {
+	switch n := n.(type) {
+	case *Ident:
+		return n.Name == variable
+	case *Unary:
+		return dependsOn(n.X, variable)
+	case *Binary:
+		return dependsOn(n.X, variable) || dependsOn(n.Y, variable)
+	case *Call:
+		for _, arg := range n.Args {
+			if dependsOn(arg, variable) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Simplify folds constants and removes identities such as x*1, x+0 and x^1
+func Simplify(n Node) Node {
+	for {
+		next := simplify(n)
+		if next.String() == n.String() {
+			return next
+		}
+		n = next
+	}
+}
+
+func simplify(n Node) Node {
+	switch n := n.(type) {
+	case *Unary:
+		return simplifyUnary(n.Op, simplify(n.X))
+	case *Binary:
+		return simplifyBinary(n.Op, simplify(n.X), simplify(n.Y))
+	case *Call:
+		args := make([]Node, len(n.Args))
+		values := make([]float64, len(n.Args))
+		constant := true
+		for i, arg := range n.Args {
+			args[i] = simplify(arg)
+			v, ok := constValue(args[i])
+			values[i], constant = v, constant && ok
+		}
+		// Only fold calls with exact finite results, so log(2) and log(0) stay symbolic
+		// but log(1) becomes 0 and log(e) becomes 1
+		if b, ok := builtins[n.Name]; ok && constant && len(values) >= b.minArgs && (b.maxArgs < 0 || len(values) <= b.maxArgs) {
+			if v := b.fn(values); v == math.Trunc(v) && !math.IsInf(v, 0) {
+				return num(v)
+			}
+		}
+		return &Call{Name: n.Name, Args: args}
+	}
+	return n
+}
+
+func simplifyUnary(op rune, x Node) Node {
+	if op == '+' {
+		return x
+	}
+	if v, ok := numValue(x); ok {
+		return num(Subtract(0, v))
+	}
+	if u, ok := x.(*Unary); ok && u.Op == '-' {
+		return u.X
+	}
+	// Negate the coefficient of a product: -(2*x) is -2*x
+	if b, ok := x.(*Binary); ok && b.Op == '*' {
+		if c, ok := numValue(b.X); ok {
+			return bin('*', num(-c), b.Y)
+		}
+	}
+	return &Unary{Op: op, X: x}
+}
+
+func simplifyBinary(op rune, x, y Node) Node {
+	xv, xNum := numValue(x)
+	yv, yNum := numValue(y)
+	if xNum && yNum {
+		if v, err := applyBinary(op, xv, yv); err == nil && !math.IsInf(v, 0) && !math.IsNaN(v) {
+			return num(v)
+		}
+		// Operations without a finite result, such as 0/0, are left unevaluated
+		return bin(op, x, y)
+	}
+
+	switch op {
+	case '+':
+		switch {
+		case xNum && xv == 0:
+			return y
+		case yNum && yv == 0:
+			return x
+		case yNum && yv < 0:
+			return bin('-', x, num(-yv))
+		case isNeg(y):
+			return bin('-', x, y.(*Unary).X)
+		case sameNode(x, y):
+			return bin('*', num(2), x)
+		}
+	case '-':
+		switch {
+		case yNum && yv == 0:
+			return x
+		case xNum && xv == 0:
+			return simplifyUnary('-', y)
+		case yNum && yv < 0:
+			return bin('+', x, num(-yv))
+		case isNeg(y):
+			return bin('+', x, y.(*Unary).X)
+		case sameNode(x, y):
+			return num(0)
+		}
+	case '*':
+		switch {
+		case (xNum && xv == 0) || (yNum && yv == 0):
+			return num(0)
+		case xNum && xv == 1:
+			return y
+		case yNum && yv == 1:
+			return x
+		case xNum && xv == -1:
+			return simplifyUnary('-', y)
+		case yNum && !xNum:
+			// Keep numeric coefficients on the left: x*2 is 2*x
+			return bin('*', y, x)
+		case isNeg(x):
+			return simplifyUnary('-', bin('*', x.(*Unary).X, y))
+		case isNeg(y):
+			return simplifyUnary('-', bin('*', x, y.(*Unary).X))
+		case sameNode(x, y):
+			return bin('^', x, num(2))
+		}
+		// Fold nested coefficients: 2*(3*x) is 6*x
+		if inner, ok := y.(*Binary); ok && inner.Op == '*' && xNum {
+			if c, ok := numValue(inner.X); ok {
+				return bin('*', num(xv*c), inner.Y)
+			}
+		}
+	case '/':
+		switch {
+		case xNum && xv == 0:
+			return num(0)
+		case yNum && yv == 1:
+			return x
+		case sameNode(x, y):
+			return num(1)
+		}
+	case '^':
+		switch {
+		case yNum && yv == 0:
+			return num(1)
+		case yNum && yv == 1:
+			return x
+		case xNum && xv == 1:
+			return num(1)
+		}
+	}
+	return bin(op, x, y)
+}
+
+// numValue returns the value of n if it is a numeric literal
+func numValue(n Node) (float64, bool) {
+	if n, ok := n.(*Number); ok {
+		return n.Value, true
+	}
+	return 0, false
+}
+
+// constValue returns the value of n if it is a numeric literal or a constant such as e
+func constValue(n Node) (float64, bool) {
+	if id, ok := n.(*Ident); ok {
+		v, ok := constants[id.Name]
+		return v, ok
+	}
+	return numValue(n)
+}
+
+func isNeg(n Node) bool {
+	u, ok := n.(*Unary)
+	return ok && u.Op == '-'
+}
+
+func sameNode(a, b Node) bool {
+	return a.String() == b.String()
+}
+
+func num(v float64) Node {
+	return &Number{Value: v}
+}
+
+func bin(op rune, x, y Node) Node {
+	return &Binary{Op: op, X: x, Y: y}
+}
+
+func call(name string, args ...Node) Node {
+	return &Call{Name: name, Args: args}
+}
+
+// LaTeX formats n as a LaTeX math expression, e.g. \frac{1}{x^{2}}
+func LaTeX(n Node) string {
+	switch n := n.(type) {
+	case *Number:
+		return strconv.FormatFloat(n.Value, 'g', -1, 64)
+	case *Imaginary:
+		return strconv.FormatFloat(n.Value, 'g', -1, 64) + "i"
+	case *Ident:
+		if n.Name == "pi" {
+			return `\pi`
+		}
+		return n.Name
+	case *Unary:
+		return string(n.Op) + latexWrap(n.X, precPower)
+	case *Binary:
+		switch n.Op {
+		case '/':
+			return `\frac{` + LaTeX(n.X) + "}{" + LaTeX(n.Y) + "}"
+		case '^':
+			return latexWrap(n.X, precAtom) + "^{" + LaTeX(n.Y) + "}"
+		case '*':
+			prec := precMultiplicative
+			return latexWrap(n.X, prec) + ` \cdot ` + latexWrap(n.Y, prec+1)
+		default:
+			prec := precAdditive
+			return latexWrap(n.X, prec) + " " + string(n.Op) + " " + latexWrap(n.Y, prec+1)
+		}
+	case *Call:
+		args := make([]string, len(n.Args))
+		for i, arg := range n.Args {
+			args[i] = LaTeX(arg)
+		}
+		joined := strings.Join(args, ", ")
+		switch n.Name {
+		case "sqrt":
+			return `\sqrt{` + joined + "}"
+		case "abs":
+			return `\left|` + joined + `\right|`
+		case "sin", "cos", "log", "exp", "min", "max":
+			return `\` + n.Name + `\left(` + joined + `\right)`
+		}
+		return `\operatorname{` + n.Name + `}\left(` + joined + `\right)`
+	}
+	return n.String()
+}
+
+// latexWrap formats n as LaTeX, adding parentheses when it binds looser than prec
+// Fractions are delimited by their braces, so they only need parentheses as a base
+func latexWrap(n Node, prec int) string {
+	p := nodePrec(n)
+	if b, ok := n.(*Binary); ok && b.Op == '/' {
+		p = precPower
+	}
+	if p < prec {
+		return `\left(` + LaTeX(n) + `\right)`
+	}
+	return LaTeX(n)
+}
@@ -0,0 +1,171 @@
+package calculator
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDerive(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected string
+	}{
+		{"5", "0"},
+		{"x", "1"},
+		{"y", "0"},
+		{"x^3 + 2*x", "3 * x^2 + 2"},
+		{"x * x", "2 * x"},
+		{"-x", "-1"},
+		{"1 / x", "-1 / x^2"},
+		{"sin(x)", "cos(x)"},
+		{"cos(2*x)", "-2 * sin(2 * x)"},
+		{"log(x)", "1 / x"},
+		{"sqrt(x)", "1 / (2 * sqrt(x))"},
+		{"2^x", "2^x * log(2)"},
+		{"e^x", "e^x"},
+		{"x^x", "x^x * (log(x) + 1)"},
+		{"pow(x, 3)", "3 * x^2"},
+		{"x * y", "y"},
+		{"sin(x) * cos(x)", "cos(x)^2 - sin(x)^2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			d, err := Derive(tt.expr, "x")
+			if err != nil {
+				t.Fatalf("Derive(%q) unexpected error: %v", tt.expr, err)
+			}
+			if d.String() != tt.expected {
+				t.Errorf("Derive(%q) = %q; want %q", tt.expr, d.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestDeriveMatchesFiniteDifferences(t *testing.T) {
+	exprs := []string{
+		"x^3 - 4*x^2 + x - 7",
+		"(x + 1) / (x - 1)",
+		"exp(x^2) * sin(x)",
+		"log(x^2 + 1) / sqrt(x)",
+		"x^x",
+		"abs(x - 3) * cos(x)",
+	}
+
+	for _, expr := range exprs {
+		t.Run(expr, func(t *testing.T) {
+			d, err := Derive(expr, "x")
+			if err != nil {
+				t.Fatalf("Derive(%q) unexpected error: %v", expr, err)
+			}
+			for _, x := range []float64{0.5, 1.7, 2.3} {
+				env := NewEnv()
+				env.Set("x", x)
+				got, err := env.Eval(d.String())
+				if err != nil {
+					t.Fatalf("evaluating %q: %v", d, err)
+				}
+				const h = 1e-6
+				env.Set("x", x+h)
+				fp, _ := env.Eval(expr)
+				env.Set("x", x-h)
+				fm, _ := env.Eval(expr)
+				want := (fp - fm) / (2 * h)
+				if math.Abs(got-want) > 1e-5*math.Max(1, math.Abs(want)) {
+					t.Errorf("d/dx %s at %v = %v; finite difference %v", expr, x, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDeriveErrors(t *testing.T) {
+	for _, expr := range []string{"max(x, 1)", "f(x)", "x +"} {
+		if _, err := Derive(expr, "x"); err == nil {
+			t.Errorf("Derive(%q) expected error", expr)
+		}
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected string
+	}{
+		{"x * 1 + 0", "x"},
+		{"x^1", "x"},
+		{"x^0", "1"},
+		{"0 * x + y", "y"},
+		{"x / 1", "x"},
+		{"2 * (3 * x)", "6 * x"},
+		{"x * 2", "2 * x"},
+		{"x - x", "0"},
+		{"x / x", "1"},
+		{"--x", "x"},
+		{"1 + 2 * 3", "7"},
+		{"(x + 0) * (1 * y)", "x * y"},
+		{"log(1) + x", "x"},
+		{"sqrt(2)", "sqrt(2)"},
+		{"x + -3", "x - 3"},
+		{"1 / 0", "1 / 0"},
+		{"1e308 * 10", "1e+308 * 10"},
+		{"0 / 0 * x", "0 / 0 * x"},
+		{"0 / x", "0"},
+		{"log(0)", "log(0)"},
+		{"log(e)", "1"},
+		{"cos(pi) + x", "-1 + x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			n, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error: %v", tt.expr, err)
+			}
+			// Simplify loops until the tree stops changing, so a rule that keeps rewriting hangs it
+			done := make(chan string, 1)
+			go func() { done <- Simplify(n).String() }()
+			select {
+			case s := <-done:
+				if s != tt.expected {
+					t.Errorf("Simplify(%q) = %q; want %q", tt.expr, s, tt.expected)
+				}
+				if _, err := Parse(s); err != nil {
+					t.Errorf("Simplify(%q) = %q, which Parse rejects: %v", tt.expr, s, err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("Simplify(%q) did not return", tt.expr)
+			}
+		})
+	}
+}
+
+func TestLaTeX(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected string
+	}{
+		{"1 / x^2", `\frac{1}{x^{2}}`},
+		{"(x + 1)^2", `\left(x + 1\right)^{2}`},
+		{"2 * pi * r", `2 \cdot \pi \cdot r`},
+		{"sqrt(x) + abs(y)", `\sqrt{x} + \left|y\right|`},
+		{"sin(x)^2", `\sin\left(x\right)^{2}`},
+		{"(1 / x)^2", `\left(\frac{1}{x}\right)^{2}`},
+		{"a - (b - c)", `a - \left(b - c\right)`},
+		{"-x^2", `-x^{2}`},
+		{"f(x, y)", `\operatorname{f}\left(x, y\right)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			n, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error: %v", tt.expr, err)
+			}
+			if s := LaTeX(n); s != tt.expected {
+				t.Errorf("LaTeX(%q) = %q; want %q", tt.expr, s, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,390 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Dimension holds the exponents of the seven SI base dimensions,
+// in the order length, mass, time, current, temperature, amount and luminosity
+type Dimension [7]int
+
+// baseSymbols are the SI units of each base dimension, in Dimension order
+var baseSymbols = [7]string{"m", "kg", "s", "A", "K", "mol", "cd"}
+
+var (
+	dimLength      = Dimension{1, 0, 0, 0, 0, 0, 0}
+	dimMass        = Dimension{0, 1, 0, 0, 0, 0, 0}
+	dimTime        = Dimension{0, 0, 1, 0, 0, 0, 0}
+	dimCurrent     = Dimension{0, 0, 0, 1, 0, 0, 0}
+	dimTemperature = Dimension{0, 0, 0, 0, 1, 0, 0}
+	dimAmount      = Dimension{0, 0, 0, 0, 0, 1, 0}
+	dimLuminosity  = Dimension{0, 0, 0, 0, 0, 0, 1}
+)
+
+// String formats the dimension with SI base units, e.g. "m/s^2" or "m^2 kg/s^3"
+func (d Dimension) String() string {
+	var num, den []string
+	for i, exp := range d {
+		switch {
+		case exp == 1:
+			num = append(num, baseSymbols[i])
+		case exp > 1:
+			num = append(num, fmt.Sprintf("%s^%d", baseSymbols[i], exp))
+		case exp == -1:
+			den = append(den, baseSymbols[i])
+		case exp < -1:
+			den = append(den, fmt.Sprintf("%s^%d", baseSymbols[i], -exp))
+		}
+	}
+
+	s := strings.Join(num, " ")
+	switch {
+	case len(den) == 0:
+		return s
+	case len(num) == 0:
+		s = "1"
+	}
+	if len(den) > 1 {
+		return s + "/(" + strings.Join(den, " ") + ")"
+	}
+	return s + "/" + den[0]
+}
+
+func (d Dimension) add(o Dimension, sign int) Dimension {
+	for i := range d {
+		d[i] += sign * o[i]
+	}
+	return d
+}
+
+// DimensionError reports an operation between quantities of incompatible dimensions
+type DimensionError struct {
+	Op          string
+	Left, Right Dimension
+}
+
+func (e *DimensionError) Error() string {
+	return fmt.Sprintf("cannot %s %s and %s: incompatible dimensions", e.Op, describeDim(e.Left), describeDim(e.Right))
+}
+
+func describeDim(d Dimension) string {
+	if d == (Dimension{}) {
+		return "a dimensionless value"
+	}
+	return d.String()
+}
+
+// Quantity is a value with a physical dimension
+// Value is expressed in SI base units, so 5 km is stored as 5000 with dimension m
+type Quantity struct {
+	Value float64
+	Dim   Dimension
+}
+
+func (q Quantity) String() string {
+	if q.Dim == (Dimension{}) {
+		return fmt.Sprint(q.Value)
+	}
+	return fmt.Sprintf("%v %s", q.Value, q.Dim)
+}
+
+// Add returns q + o
+// Returns a *DimensionError if the dimensions differ
+func (q Quantity) Add(o Quantity) (Quantity, error) {
+	if q.Dim != o.Dim {
+		return Quantity{}, &DimensionError{Op: "add", Left: q.Dim, Right: o.Dim}
+	}
+	return Quantity{Add(q.Value, o.Value), q.Dim}, nil
+}
+
+// Subtract returns q - o
+// Returns a *DimensionError if the dimensions differ
+func (q Quantity) Subtract(o Quantity) (Quantity, error) {
+	if q.Dim != o.Dim {
+		return Quantity{}, &DimensionError{Op: "subtract", Left: q.Dim, Right: o.Dim}
+	}
+	return Quantity{Subtract(q.Value, o.Value), q.Dim}, nil
+}
+
+// Multiply returns q × o, adding the exponents of their dimensions
+func (q Quantity) Multiply(o Quantity) Quantity {
+	return Quantity{Multiply(q.Value, o.Value), q.Dim.add(o.Dim, 1)}
+}
+
+// Divide returns q / o, subtracting the exponents of their dimensions
+// If o is 0, returns ErrDivisionByZero
+func (q Quantity) Divide(o Quantity) (Quantity, error) {
+	v, err := Divide(q.Value, o.Value)
+	if err != nil {
+		return Quantity{}, err
+	}
+	return Quantity{v, q.Dim.add(o.Dim, -1)}, nil
+}
+
+// Power raises q to exponent, multiplying the exponents of its dimension
+// Returns an error if that leaves a fractional exponent, as in m^0.5
+func (q Quantity) Power(exponent float64) (Quantity, error) {
+	var dim Dimension
+	for i, exp := range q.Dim {
+		scaled := float64(exp) * exponent
+		if scaled != math.Trunc(scaled) {
+			return Quantity{}, fmt.Errorf("cannot raise %s to %v: fractional dimension", q.Dim, exponent)
+		}
+		dim[i] = int(scaled)
+	}
+	return Quantity{Power(q.Value, exponent), dim}, nil
+}
+
+// Unit is a named unit of measure
+// Factor converts one of the unit into SI base units
+type Unit struct {
+	Name       string
+	Factor     float64
+	Dim        Dimension
+	prefixable bool
+}
+
+// prefixes are the SI prefixes that can precede prefixable units, e.g. k in km
+// "da" comes first so that dam is a decametre and not a deci-"am"
+var prefixes = []struct {
+	symbol string
+	factor float64
+}{
+	{"da", 1e1}, {"Q", 1e30}, {"R", 1e27}, {"Y", 1e24}, {"Z", 1e21}, {"E", 1e18},
+	{"P", 1e15}, {"T", 1e12}, {"G", 1e9}, {"M", 1e6}, {"k", 1e3}, {"h", 1e2},
+	{"d", 1e-1}, {"c", 1e-2}, {"m", 1e-3}, {"u", 1e-6}, {"µ", 1e-6}, {"n", 1e-9},
+	{"p", 1e-12}, {"f", 1e-15}, {"a", 1e-18}, {"z", 1e-21}, {"y", 1e-24}, {"r", 1e-27}, {"q", 1e-30},
+}
+
+// UnitRegistry resolves unit names such as "km", "h" or "lb"
+type UnitRegistry struct {
+	units map[string]Unit
+}
+
+// NewUnitRegistry creates a registry with the SI base units, SI prefixes,
+// common derived units and common imperial units
+func NewUnitRegistry() *UnitRegistry {
+	r := &UnitRegistry{units: map[string]Unit{}}
+
+	// SI base units; the gram takes prefixes so that kg resolves as k + g
+	r.define("m", 1, dimLength, true)
+	r.define("g", 1e-3, dimMass, true)
+	r.define("s", 1, dimTime, true)
+	r.define("A", 1, dimCurrent, true)
+	r.define("K", 1, dimTemperature, true)
+	r.define("mol", 1, dimAmount, true)
+	r.define("cd", 1, dimLuminosity, true)
+
+	// Derived SI units
+	force := dimMass.add(dimLength, 1).add(dimTime, -2)
+	energy := force.add(dimLength, 1)
+	power := energy.add(dimTime, -1)
+	charge := dimCurrent.add(dimTime, 1)
+	r.define("Hz", 1, Dimension{}.add(dimTime, -1), true)
+	r.define("N", 1, force, true)
+	r.define("Pa", 1, force.add(dimLength, -2), true)
+	r.define("J", 1, energy, true)
+	r.define("W", 1, power, true)
+	r.define("C", 1, charge, true)
+	r.define("V", 1, power.add(dimCurrent, -1), true)
+	r.define("ohm", 1, power.add(dimCurrent, -2), true)
+	r.define("L", 1e-3, Dimension{3}, true)
+	r.define("t", 1e3, dimMass, false)
+	r.define("min", 60, dimTime, false)
+	r.define("h", 3600, dimTime, false)
+	r.define("day", 86400, dimTime, false)
+	r.define("ha", 1e4, Dimension{2}, false)
+	r.define("Wh", 3600, energy, true)
+	r.define("eV", 1.602176634e-19, energy, true)
+	r.define("bar", 1e5, force.add(dimLength, -2), true)
+
+	// Imperial and US customary units; "in" is reserved for conversions, so the inch is "inch"
+	r.define("inch", 0.0254, dimLength, false)
+	r.define("ft", 0.3048, dimLength, false)
+	r.define("yd", 0.9144, dimLength, false)
+	r.define("mi", 1609.344, dimLength, false)
+	r.define("nmi", 1852, dimLength, false)
+	r.define("lb", 0.45359237, dimMass, false)
+	r.define("oz", 0.028349523125, dimMass, false)
+	r.define("gal", 3.785411784e-3, Dimension{3}, false)
+	r.define("mph", 1609.344/3600, dimLength.add(dimTime, -1), false)
+	r.define("kn", 1852.0/3600, dimLength.add(dimTime, -1), false)
+	r.define("lbf", 0.45359237*9.80665, force, false)
+	r.define("psi", 0.45359237*9.80665/(0.0254*0.0254), force.add(dimLength, -2), false)
+	r.define("cal", 4.184, energy, true)
+	r.define("hp", 745.69987158227022, power, false)
+
+	return r
+}
+
+func (r *UnitRegistry) define(name string, factor float64, dim Dimension, prefixable bool) {
+	r.units[name] = Unit{Name: name, Factor: factor, Dim: dim, prefixable: prefixable}
+}
+
+// Define adds or replaces a unit equal to factor times the quantity described by def,
+// e.g. Define("furlong", 220, "yd")
+func (r *UnitRegistry) Define(name string, factor float64, def string) error {
+	q, err := r.Parse(def)
+	if err != nil {
+		return err
+	}
+	r.define(name, factor*q.Value, q.Dim, false)
+	return nil
+}
+
+// Lookup returns the unit with the given name, trying SI prefixes if it is not defined as is
+func (r *UnitRegistry) Lookup(name string) (Unit, bool) {
+	if u, ok := r.units[name]; ok {
+		return u, true
+	}
+	for _, p := range prefixes {
+		base, found := strings.CutPrefix(name, p.symbol)
+		if u, ok := r.units[base]; found && ok && u.prefixable {
+			return Unit{Name: name, Factor: p.factor * u.Factor, Dim: u.Dim}, true
+		}
+	}
+	return Unit{}, false
+}
+
+// Parse evaluates an expression of numbers and units such as "5 km / 20 min"
+// Juxtaposition multiplies, so "3 N m" is 3 newton-metres
+func (r *UnitRegistry) Parse(expr string) (Quantity, error) {
+	n, err := parse(expr, true)
+	if err != nil {
+		return Quantity{}, err
+	}
+	return r.eval(n)
+}
+
+// Convert returns the value of q expressed in unit, e.g. Convert(q, "km/h")
+// Returns a *DimensionError if unit measures a different dimension
+func (r *UnitRegistry) Convert(q Quantity, unit string) (float64, error) {
+	u, err := r.Parse(unit)
+	if err != nil {
+		return 0, err
+	}
+	if u.Dim != q.Dim {
+		return 0, &DimensionError{Op: "convert", Left: q.Dim, Right: u.Dim}
+	}
+	return Divide(q.Value, u.Value)
+}
+
+// Evaluate evaluates an expression with units, optionally followed by "in <unit>",
+// and returns the value in that unit, e.g. "5 km / 20 min in m/s" is 4.1667 "m/s"
+// Without a target unit the result is given in SI base units
+func (r *UnitRegistry) Evaluate(expr string) (float64, string, error) {
+	expr, target, err := splitConversion(expr)
+	if err != nil {
+		return 0, "", err
+	}
+	q, err := r.Parse(expr)
+	if err != nil {
+		return 0, "", err
+	}
+	if target == "" {
+		return q.Value, q.Dim.String(), nil
+	}
+	v, err := r.Convert(q, target)
+	if err != nil {
+		return 0, "", err
+	}
+	return v, target, nil
+}
+
+// splitConversion splits "expr in unit" at its last "in"
+// Returns a *SyntaxError if either side of the "in" is empty
+func splitConversion(expr string) (string, string, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return "", "", err
+	}
+	for i := len(tokens) - 1; i >= 0; i-- {
+		if tok := tokens[i]; tok.kind == tokIdent && tok.text == "in" {
+			if i == 0 {
+				return "", "", &SyntaxError{Col: tok.col, Msg: "missing quantity before 'in'"}
+			}
+			if i == len(tokens)-2 {
+				return "", "", &SyntaxError{Col: tokens[i+1].col, Msg: "missing unit after 'in'"}
+			}
+			src := []rune(expr)
+			return string(src[:tok.col-1]), strings.TrimSpace(string(src[tok.col+1:])), nil
+		}
+	}
+	return expr, "", nil
+}
+
+func (r *UnitRegistry) eval(n Node) (Quantity, error) {
+	switch n := n.(type) {
+	case *Number:
+		return Quantity{Value: n.Value}, nil
+	case *Ident:
+		u, ok := r.Lookup(n.Name)
+		if !ok {
+			return Quantity{}, &UndefinedError{Kind: "unit", Name: n.Name, Col: n.Pos()}
+		}
+		return Quantity{u.Factor, u.Dim}, nil
+	case *Unary:
+		q, err := r.eval(n.X)
+		if err != nil {
+			return Quantity{}, err
+		}
+		if n.Op == '-' {
+			q.Value = -q.Value
+		}
+		return q, nil
+	case *Binary:
+		x, err := r.eval(n.X)
+		if err != nil {
+			return Quantity{}, err
+		}
+		y, err := r.eval(n.Y)
+		if err != nil {
+			return Quantity{}, err
+		}
+		switch n.Op {
+		case '+':
+			return x.Add(y)
+		case '-':
+			return x.Subtract(y)
+		case '*':
+			return x.Multiply(y), nil
+		case '/':
+			return x.Divide(y)
+		case '^':
+			if y.Dim != (Dimension{}) {
+				return Quantity{}, fmt.Errorf("exponent at column %d must be dimensionless, got %s", n.Y.Pos(), y.Dim)
+			}
+			return x.Power(y.Value)
+		}
+	case *Call:
+		if n.Name != "sqrt" || len(n.Args) != 1 {
+			return Quantity{}, fmt.Errorf("function %s at column %d is not supported with units", n.Name, n.Pos())
+		}
+		q, err := r.eval(n.Args[0])
+		if err != nil {
+			return Quantity{}, err
+		}
+		return q.Power(0.5)
+	}
+	return Quantity{}, fmt.Errorf("unsupported node %T", n)
+}
+
+// defaultUnits is the registry used by the package-level unit functions
+var defaultUnits = NewUnitRegistry()
+
+// ParseQuantity evaluates an expression with units using the default registry
+func ParseQuantity(expr string) (Quantity, error) {
+	return defaultUnits.Parse(expr)
+}
+
+// Convert returns the value of q expressed in unit using the default registry
+func Convert(q Quantity, unit string) (float64, error) {
+	return defaultUnits.Convert(q, unit)
+}
+
+// EvaluateUnits evaluates "expr" or "expr in unit" using the default registry
+func EvaluateUnits(expr string) (float64, string, error) {
+	return defaultUnits.Evaluate(expr)
+}
@@ -0,0 +1,134 @@
+package calculator
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestEvaluateUnits(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected float64
+		unit     string
+	}{
+		{"5 km / 20 min in m/s", 5000.0 / 1200, "m/s"},
+		{"5 km / 20 min in km/h", 15, "km/h"},
+		{"5 km / 20 min", 5000.0 / 1200, "m/s"},
+		{"60 mph in km/h", 96.56064, "km/h"},
+		{"1 inch in cm", 2.54, "cm"},
+		{"3 ft + 2 inch in cm", 96.52, "cm"},
+		{"2 kg * 9.8 m/s^2 in N", 19.6, "N"},
+		{"1 kW h in J", 3.6e6, "J"},
+		{"1 kWh in MJ", 3.6, "MJ"},
+		{"10 m^2 * 2 m in L", 20000, "L"},
+		{"sqrt(16 m^2) in m", 4, "m"},
+		{"1 lb in g", 453.59237, "g"},
+		{"1 gal in L", 3.785411784, "L"},
+		{"100 dam in km", 1, "km"},
+		{"2 N m", 2, "m^2 kg/s^2"},
+		{"3 / s", 3, "1/s"},
+		{"4 / (s mol)", 4, "1/(s mol)"},
+		{"1.5", 1.5, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			v, unit, err := EvaluateUnits(tt.expr)
+			if err != nil {
+				t.Fatalf("EvaluateUnits(%q) unexpected error: %v", tt.expr, err)
+			}
+			if math.Abs(v-tt.expected) > 1e-9*math.Max(1, math.Abs(tt.expected)) || unit != tt.unit {
+				t.Errorf("EvaluateUnits(%q) = %v %q; want %v %q", tt.expr, v, unit, tt.expected, tt.unit)
+			}
+		})
+	}
+}
+
+func TestUnitErrors(t *testing.T) {
+	var dimErr *DimensionError
+	if _, _, err := EvaluateUnits("3 m + 2 s"); !errors.As(err, &dimErr) {
+		t.Errorf("adding m and s error = %v; want *DimensionError", err)
+	} else if err.Error() != "cannot add m and s: incompatible dimensions" {
+		t.Errorf("adding m and s error = %q", err.Error())
+	}
+	if _, _, err := EvaluateUnits("3 m in s"); !errors.As(err, &dimErr) {
+		t.Errorf("converting m to s error = %v; want *DimensionError", err)
+	}
+	if _, _, err := EvaluateUnits("3 m - 1"); !errors.As(err, &dimErr) {
+		t.Errorf("subtracting a number from m error = %v; want *DimensionError", err)
+	}
+
+	var undefined *UndefinedError
+	if _, _, err := EvaluateUnits("3 parsecs"); !errors.As(err, &undefined) || undefined.Kind != "unit" {
+		t.Errorf("unknown unit error = %v; want *UndefinedError of kind unit", err)
+	}
+	if _, _, err := EvaluateUnits("1 m / (0 s)"); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("division by zero error = %v; want %v", err, ErrDivisionByZero)
+	}
+	if _, _, err := EvaluateUnits("2 m ^ 0.5"); err == nil {
+		t.Errorf("fractional dimension expected error")
+	}
+	if _, _, err := EvaluateUnits("2 ^ (1 m)"); err == nil {
+		t.Errorf("exponent with units expected error")
+	}
+
+	var syntaxErr *SyntaxError
+	for _, expr := range []string{"3 in", "3 m in  ", "in m"} {
+		if _, _, err := EvaluateUnits(expr); !errors.As(err, &syntaxErr) {
+			t.Errorf("EvaluateUnits(%q) error = %v; want *SyntaxError", expr, err)
+		}
+	}
+	if _, _, err := EvaluateUnits("3 in"); err == nil || err.Error() != "missing unit after 'in' at column 5" {
+		t.Errorf("EvaluateUnits(\"3 in\") error = %v; want missing unit after 'in' at column 5", err)
+	}
+}
+
+func TestQuantityArithmetic(t *testing.T) {
+	distance, _ := ParseQuantity("5 km")
+	duration, _ := ParseQuantity("20 min")
+
+	speed, err := distance.Divide(duration)
+	if err != nil {
+		t.Fatalf("Divide unexpected error: %v", err)
+	}
+	if speed.Dim != dimLength.add(dimTime, -1) {
+		t.Errorf("speed dimension = %s; want m/s", speed.Dim)
+	}
+	kmh, err := Convert(speed, "km/h")
+	if err != nil || math.Abs(kmh-15) > 1e-9 {
+		t.Errorf("Convert(speed, km/h) = %v, %v; want 15", kmh, err)
+	}
+
+	area := distance.Multiply(distance)
+	if area.Dim != (Dimension{2}) || area.Value != 25e6 {
+		t.Errorf("area = %v; want 2.5e+07 m^2", area)
+	}
+	side, err := area.Power(0.5)
+	if err != nil || side != distance {
+		t.Errorf("area^0.5 = %v, %v; want %v", side, err, distance)
+	}
+	if _, err := distance.Add(duration); err == nil {
+		t.Errorf("distance + duration expected error")
+	}
+	if s := speed.String(); s != "4.166666666666667 m/s" {
+		t.Errorf("speed.String() = %q", s)
+	}
+}
+
+func TestUnitRegistryDefine(t *testing.T) {
+	r := NewUnitRegistry()
+	if err := r.Define("furlong", 220, "yd"); err != nil {
+		t.Fatalf("Define unexpected error: %v", err)
+	}
+	if err := r.Define("fortnight", 14, "day"); err != nil {
+		t.Fatalf("Define unexpected error: %v", err)
+	}
+	v, unit, err := r.Evaluate("1 furlong / fortnight in mm/h")
+	if err != nil || math.Abs(v-201168.0/336) > 1e-9 || unit != "mm/h" {
+		t.Errorf("furlong per fortnight = %v %q, %v", v, unit, err)
+	}
+	if _, ok := NewUnitRegistry().Lookup("furlong"); ok {
+		t.Errorf("Define on one registry leaked into another")
+	}
+}
@@ -0,0 +1,108 @@
+package stringutils
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SplitIdentifier splits an identifier into lowercase words at separators and case changes,
+// e.g. "HTTPServerID" gives http, server and id, and "user_name-2" gives user, name and 2
+// Digits stay with the word before them, so "base64Encode" gives base64 and encode
+func SplitIdentifier(s string) []string {
+	runes := []rune(s)
+	var words []string
+	start := -1
+	for i, r := range runes {
+		if !isIdentifierRune(r) {
+			if start >= 0 {
+				words = append(words, strings.ToLower(string(runes[start:i])))
+			}
+			start = -1
+			continue
+		}
+		if start >= 0 && identifierBoundary(runes, i) {
+			words = append(words, strings.ToLower(string(runes[start:i])))
+			start = i
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, strings.ToLower(string(runes[start:])))
+	}
+	return words
+}
+
+// isIdentifierRune reports whether r belongs to a word; anything else is a separator
+func isIdentifierRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r)
+}
+
+func isUpperRune(r rune) bool {
+	return unicode.IsUpper(r) || unicode.IsTitle(r)
+}
+
+// identifierBoundary reports whether a new word starts at runes[i]
+func identifierBoundary(runes []rune, i int) bool {
+	prev, cur := runes[i-1], runes[i]
+	switch {
+	case isUpperRune(cur) && !isUpperRune(prev):
+		// "camelCase", "base64Encode", "日本語Text"
+		return true
+	case isUpperRune(prev) && isUpperRune(cur) && i+1 < len(runes) && unicode.IsLower(runes[i+1]):
+		// The last capital of an acronym starts the next word: "HTTPServer"
+		return true
+	}
+	return false
+}
+
+// capitalizeWord converts the first rune of a lowercase word to title case
+func capitalizeWord(w string) string {
+	r, size := utf8.DecodeRuneInString(w)
+	return string(unicode.ToTitle(r)) + w[size:]
+}
+
+// ToCamelCase converts an identifier to camelCase, e.g. "HTTPServerID" becomes "httpServerId"
+func ToCamelCase(s string) string {
+	words := SplitIdentifier(s)
+	for i := 1; i < len(words); i++ {
+		words[i] = capitalizeWord(words[i])
+	}
+	return strings.Join(words, "")
+}
+
+// ToPascalCase converts an identifier to PascalCase, e.g. "http_server_id" becomes "HttpServerId"
+func ToPascalCase(s string) string {
+	words := SplitIdentifier(s)
+	for i := range words {
+		words[i] = capitalizeWord(words[i])
+	}
+	return strings.Join(words, "")
+}
+
+// ToSnakeCase converts an identifier to snake_case, e.g. "HTTPServerID" becomes "http_server_id"
+func ToSnakeCase(s string) string {
+	return strings.Join(SplitIdentifier(s), "_")
+}
+
+// ToKebabCase converts an identifier to kebab-case, e.g. "HTTPServerID" becomes "http-server-id"
+func ToKebabCase(s string) string {
+	return strings.Join(SplitIdentifier(s), "-")
+}
+
+// ToConstantCase converts an identifier to SCREAMING_SNAKE_CASE, e.g. "httpServerId" becomes "HTTP_SERVER_ID"
+func ToConstantCase(s string) string {
+	return strings.ToUpper(ToSnakeCase(s))
+}
+
+// ToTitleWords converts an identifier to capitalized words separated by spaces,
+// e.g. "httpServerId" becomes "Http Server Id"; unlike ToTitleCase it also splits at case changes
+func ToTitleWords(s string) string {
+	words := SplitIdentifier(s)
+	for i := range words {
+		words[i] = capitalizeWord(words[i])
+	}
+	return strings.Join(words, " ")
+}
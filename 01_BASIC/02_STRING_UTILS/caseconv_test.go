@@ -0,0 +1,79 @@
+package stringutils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitIdentifier(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"HTTPServerID", []string{"http", "server", "id"}},
+		{"camelCase", []string{"camel", "case"}},
+		{"PascalCase", []string{"pascal", "case"}},
+		{"snake_case_name", []string{"snake", "case", "name"}},
+		{"kebab-case--name", []string{"kebab", "case", "name"}},
+		{"SCREAMING_SNAKE", []string{"screaming", "snake"}},
+		{"  spaced out words ", []string{"spaced", "out", "words"}},
+		{"base64Encode", []string{"base64", "encode"}},
+		{"HTTP2Server", []string{"http2", "server"}},
+		{"user_name-2", []string{"user", "name", "2"}},
+		{"ÁrbolDeNavidad", []string{"árbol", "de", "navidad"}},
+		{"straßeNummer", []string{"straße", "nummer"}},
+		{"日本語Text", []string{"日本語", "text"}},
+		{"A", []string{"a"}},
+		{"", nil},
+		{"__", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if result := SplitIdentifier(tt.input); !slices.Equal(result, tt.expected) {
+				t.Errorf("SplitIdentifier(%q) = %q; want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCaseConversions(t *testing.T) {
+	tests := []struct {
+		name     string
+		fn       func(string) string
+		input    string
+		expected string
+	}{
+		{"camel", ToCamelCase, "HTTPServerID", "httpServerId"},
+		{"camel from snake", ToCamelCase, "user_id", "userId"},
+		{"pascal", ToPascalCase, "http_server_id", "HttpServerId"},
+		{"pascal unicode", ToPascalCase, "élan vital", "ÉlanVital"},
+		{"snake", ToSnakeCase, "HTTPServerID", "http_server_id"},
+		{"snake with digits", ToSnakeCase, "base64Encode", "base64_encode"},
+		{"kebab", ToKebabCase, "userProfileURL", "user-profile-url"},
+		{"constant", ToConstantCase, "maxRetryCount", "MAX_RETRY_COUNT"},
+		{"constant unicode", ToConstantCase, "straßeNummer", "STRAßE_NUMMER"},
+		{"title words", ToTitleWords, "httpServerId", "Http Server Id"},
+		{"title words from kebab", ToTitleWords, "hello-world", "Hello World"},
+		{"empty", ToCamelCase, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.fn(tt.input); result != tt.expected {
+				t.Errorf("%s(%q) = %q; want %q", tt.name, tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCaseConversionRoundTrip(t *testing.T) {
+	for _, input := range []string{"HTTPServerID", "user_name", "base64Encode", "ÁrbolDeNavidad"} {
+		snake := ToSnakeCase(input)
+		for _, converted := range []string{ToCamelCase(input), ToPascalCase(input), ToKebabCase(input), ToConstantCase(input)} {
+			if result := ToSnakeCase(converted); result != snake {
+				t.Errorf("ToSnakeCase(%q) = %q; want %q", converted, result, snake)
+			}
+		}
+	}
+}
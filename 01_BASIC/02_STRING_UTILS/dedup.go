@@ -0,0 +1,120 @@
+package stringutils
+
+import (
+	"errors"
+	"strings"
+)
+
+// DedupOptions control which duplicate characters RemoveDuplicates removes
+// The zero value keeps the first occurrence of every character, as RemoveDuplicateChars does
+type DedupOptions struct {
+	// KeepLast keeps the last occurrence of each character instead of the first
+	KeepLast bool
+	// Adjacent only removes repeats next to each other, squeezing "aaabba" to "aba"
+	Adjacent bool
+	// IgnoreCase treats the uppercase and lowercase forms of a letter as the same character
+	IgnoreCase bool
+}
+
+func (o DedupOptions) key(r rune) rune {
+	if o.IgnoreCase {
+		return foldRune(r)
+	}
+	return r
+}
+
+// RemoveDuplicates removes duplicate characters from s as opts select
+func RemoveDuplicates(s string, opts DedupOptions) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	switch {
+	case opts.Adjacent:
+		t := newDedupTransformer(opts)
+		for _, r := range s {
+			if out, ok := t.step(r); ok {
+				b.WriteRune(out)
+			}
+		}
+		if out, ok := t.flush(); ok {
+			b.WriteRune(out)
+		}
+	case opts.KeepLast:
+		last := make(map[rune]int)
+		runes := []rune(s)
+		for i, r := range runes {
+			last[opts.key(r)] = i
+		}
+		for i, r := range runes {
+			if last[opts.key(r)] == i {
+				b.WriteRune(r)
+			}
+		}
+	default:
+		seen := make(map[rune]bool)
+		for _, r := range s {
+			if k := opts.key(r); !seen[k] {
+				seen[k] = true
+				b.WriteRune(r)
+			}
+		}
+	}
+	return b.String()
+}
+
+// NewDedupTransformer returns a Transformer that removes duplicate characters from a
+// stream as RemoveDuplicates does
+// Keeping the last occurrence of characters that need not be adjacent depends on the rest
+// of the stream, so that combination returns an error
+func NewDedupTransformer(opts DedupOptions) (Transformer, error) {
+	if opts.KeepLast && !opts.Adjacent {
+		return nil, errors.New("keeping the last of non-adjacent duplicates needs the whole input")
+	}
+	return newDedupTransformer(opts), nil
+}
+
+func newDedupTransformer(opts DedupOptions) *runeTransformer {
+	if !opts.Adjacent {
+		seen := make(map[rune]bool)
+		return &runeTransformer{
+			step: func(r rune) (rune, bool) {
+				k := opts.key(r)
+				if seen[k] {
+					return 0, false
+				}
+				seen[k] = true
+				return r, true
+			},
+			reset: func() {
+				clear(seen)
+			},
+		}
+	}
+
+	// prev is the last rune read; with KeepLast it is held back until its run ends
+	var prev rune
+	started := false
+	return &runeTransformer{
+		step: func(r rune) (rune, bool) {
+			held, repeated := prev, started && opts.key(r) == opts.key(prev)
+			wasStarted := started
+			prev, started = r, true
+			switch {
+			case !opts.KeepLast:
+				return r, !repeated
+			case repeated || !wasStarted:
+				return 0, false
+			}
+			return held, true
+		},
+		flush: func() (rune, bool) {
+			if !opts.KeepLast || !started {
+				return 0, false
+			}
+			started = false
+			return prev, true
+		},
+		reset: func() {
+			started = false
+		},
+	}
+}
@@ -0,0 +1,32 @@
+package stringutils
+
+import (
+	"testing"
+)
+
+func TestRemoveDuplicates(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     DedupOptions
+		input    string
+		expected string
+	}{
+		{"keep first", DedupOptions{}, "programming", "progamin"},
+		{"keep last", DedupOptions{KeepLast: true}, "programming", "poraming"},
+		{"squeeze", DedupOptions{Adjacent: true}, "aaabbaccc", "abac"},
+		{"squeeze keep last", DedupOptions{Adjacent: true, KeepLast: true, IgnoreCase: true}, "aAbBBa", "ABa"},
+		{"ignore case", DedupOptions{IgnoreCase: true}, "AaBbaC", "ABC"},
+		{"ignore case keep last", DedupOptions{IgnoreCase: true, KeepLast: true}, "AaBbaC", "baC"},
+		{"squeeze ignore case", DedupOptions{Adjacent: true, IgnoreCase: true}, "BOOokkKeeper", "BOkeper"},
+		{"unicode", DedupOptions{IgnoreCase: true}, "ÑñÉé", "ÑÉ"},
+		{"empty", DedupOptions{Adjacent: true, KeepLast: true}, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := RemoveDuplicates(tt.input, tt.opts); result != tt.expected {
+				t.Errorf("RemoveDuplicates(%q, %+v) = %q; want %q", tt.input, tt.opts, result, tt.expected)
+			}
+		})
+	}
+}
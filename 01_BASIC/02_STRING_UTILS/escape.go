@@ -0,0 +1,264 @@
+package stringutils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrInvalidEscape is wrapped by the errors of the unescaping functions for malformed input
+var ErrInvalidEscape = errors.New("invalid escape")
+
+// EscapeHTML escapes the characters that are special in HTML text and attribute values:
+// <, >, &, ' and "
+func EscapeHTML(s string) string {
+	return html.EscapeString(s)
+}
+
+// UnescapeHTML replaces the character references in s, such as "&lt;", "&eacute;" or
+// "&#233;", with the characters they stand for
+// UnescapeHTML(EscapeHTML(s)) == s for every s
+func UnescapeHTML(s string) string {
+	return html.UnescapeString(s)
+}
+
+// xmlEntities are the entities predefined by XML
+var xmlEntities = map[string]rune{"amp": '&', "lt": '<', "gt": '>', "quot": '"', "apos": '\''}
+
+// EscapeXML escapes s for XML text and attribute values: the five characters with
+// predefined entities, and tab, newline and carriage return, which attribute values
+// would otherwise normalize to spaces
+// Characters that XML 1.0 does not allow are replaced with U+FFFD
+func EscapeXML(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		switch r {
+		case '&':
+			b.WriteString("&amp;")
+		case '<':
+			b.WriteString("&lt;")
+		case '>':
+			b.WriteString("&gt;")
+		case '"':
+			b.WriteString("&quot;")
+		case '\'':
+			b.WriteString("&apos;")
+		case '\t':
+			b.WriteString("&#x9;")
+		case '\n':
+			b.WriteString("&#xA;")
+		case '\r':
+			b.WriteString("&#xD;")
+		default:
+			if !isXMLChar(r) {
+				r = utf8.RuneError
+			}
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// isXMLChar reports whether r is allowed in an XML 1.0 document
+func isXMLChar(r rune) bool {
+	return r == '\t' || r == '\n' || r == '\r' ||
+		(r >= 0x20 && r <= 0xD7FF) || (r >= 0xE000 && r <= 0xFFFD) || (r >= 0x10000 && r <= 0x10FFFF)
+}
+
+// UnescapeXML replaces the predefined entities and character references in s
+// UnescapeXML(EscapeXML(s)) == s for every s made of characters XML allows
+func UnescapeXML(s string) (string, error) {
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); {
+		amp := strings.IndexByte(s[i:], '&')
+		if amp < 0 {
+			b.WriteString(s[i:])
+			break
+		}
+		b.WriteString(s[i : i+amp])
+		i += amp
+		end := strings.IndexByte(s[i:], ';')
+		if end < 0 {
+			return "", fmt.Errorf("xml: %w: unterminated reference at byte %d", ErrInvalidEscape, i)
+		}
+		name := s[i+1 : i+end]
+		r, ok := xmlEntities[name]
+		if !ok {
+			r, ok = parseCharRef(name)
+		}
+		if !ok {
+			return "", fmt.Errorf("xml: %w: unknown reference &%s;", ErrInvalidEscape, name)
+		}
+		b.WriteRune(r)
+		i += end + 1
+	}
+	return b.String(), nil
+}
+
+// parseCharRef parses the name of a numeric character reference, "#233" or "#xE9"
+func parseCharRef(name string) (rune, bool) {
+	digits, ok := strings.CutPrefix(name, "#")
+	if !ok || digits == "" {
+		return 0, false
+	}
+	base := 10
+	if hex, ok := strings.CutPrefix(digits, "x"); ok {
+		digits, base = hex, 16
+	}
+	n, err := strconv.ParseUint(digits, base, 32)
+	if err != nil || !utf8.ValidRune(rune(n)) {
+		return 0, false
+	}
+	return rune(n), true
+}
+
+// QuoteJSON returns s as a JSON string literal, with quotes
+// <, > and & are escaped as \u003c, \u003e and \u0026 so the literal is safe inside HTML,
+// and invalid UTF-8 is replaced with U+FFFD
+func QuoteJSON(s string) string {
+	quoted, _ := json.Marshal(s)
+	return string(quoted)
+}
+
+// UnquoteJSON returns the string a JSON string literal stands for
+// UnquoteJSON(QuoteJSON(s)) == s for every valid UTF-8 s
+func UnquoteJSON(s string) (string, error) {
+	if len(s) < 2 || s[0] != '"' {
+		return "", fmt.Errorf("json: %w: not a string literal", ErrInvalidEscape)
+	}
+	var out string
+	if err := json.Unmarshal([]byte(s), &out); err != nil {
+		return "", fmt.Errorf("json: %w: %v", ErrInvalidEscape, err)
+	}
+	return out, nil
+}
+
+// QuoteShell quotes s as a single POSIX shell word, closing the quotes around each
+// single quote in s and escaping it with a backslash
+// Inside single quotes no character is special, so the word is taken literally
+func QuoteShell(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// UnquoteShell returns the word a sequence of single-quoted strings and backslash-escaped
+// characters stands for, as QuoteShell produces
+// UnquoteShell(QuoteShell(s)) == s for every s
+func UnquoteShell(s string) (string, error) {
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); {
+		switch s[i] {
+		case '\'':
+			end := strings.IndexByte(s[i+1:], '\'')
+			if end < 0 {
+				return "", fmt.Errorf("shell: %w: unterminated quote at byte %d", ErrInvalidEscape, i)
+			}
+			b.WriteString(s[i+1 : i+1+end])
+			i += end + 2
+		case '\\':
+			if i+1 == len(s) {
+				return "", fmt.Errorf("shell: %w: trailing backslash", ErrInvalidEscape)
+			}
+			_, size := utf8.DecodeRuneInString(s[i+1:])
+			b.WriteString(s[i+1 : i+1+size])
+			i += 1 + size
+		default:
+			return "", fmt.Errorf("shell: %w: unquoted character at byte %d", ErrInvalidEscape, i)
+		}
+	}
+	return b.String(), nil
+}
+
+// EscapeCSV returns s as a CSV field (RFC 4180): fields that contain a comma, a quote or
+// a line break, or that start with a space, are quoted, doubling the quotes inside
+func EscapeCSV(s string) string {
+	if !strings.ContainsAny(s, ",\"\r\n") && !strings.HasPrefix(s, " ") && !strings.HasPrefix(s, "\t") {
+		return s
+	}
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// UnescapeCSV returns the value of a single CSV field
+// UnescapeCSV(EscapeCSV(s)) == s for every s
+func UnescapeCSV(field string) (string, error) {
+	if !strings.HasPrefix(field, `"`) {
+		if strings.ContainsAny(field, ",\"\r\n") {
+			return "", fmt.Errorf("csv: %w: unquoted field with special characters", ErrInvalidEscape)
+		}
+		return field, nil
+	}
+	return unquoteDoubled(field, '"', "csv")
+}
+
+// QuoteSQL returns s as a standard SQL string literal, in single quotes with the single
+// quotes inside doubled
+// Backslashes are not special in standard SQL; prefer query parameters where the driver
+// offers them
+func QuoteSQL(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// UnquoteSQL returns the string a standard SQL string literal stands for
+// UnquoteSQL(QuoteSQL(s)) == s for every s
+func UnquoteSQL(s string) (string, error) {
+	return unquoteDoubled(s, '\'', "sql")
+}
+
+// unquoteDoubled removes the quotes around s, in which the quote character itself is doubled
+func unquoteDoubled(s string, quote byte, format string) (string, error) {
+	if len(s) < 2 || s[0] != quote || s[len(s)-1] != quote {
+		return "", fmt.Errorf("%s: %w: missing quotes", format, ErrInvalidEscape)
+	}
+	inner := s[1 : len(s)-1]
+	var b strings.Builder
+	b.Grow(len(inner))
+	for i := 0; i < len(inner); i++ {
+		if inner[i] == quote {
+			if i+1 == len(inner) || inner[i+1] != quote {
+				return "", fmt.Errorf("%s: %w: unescaped quote at byte %d", format, ErrInvalidEscape, i+1)
+			}
+			i++
+		}
+		b.WriteByte(inner[i])
+	}
+	return b.String(), nil
+}
+
+// EscapeRegexp escapes the regular expression metacharacters in s, so the result
+// matches s literally
+func EscapeRegexp(s string) string {
+	return regexp.QuoteMeta(s)
+}
+
+// UnescapeRegexp returns the literal text a regular expression made of plain characters
+// and escaped punctuation matches; escapes such as \d that match a class are an error
+// UnescapeRegexp(EscapeRegexp(s)) == s for every s
+func UnescapeRegexp(s string) (string, error) {
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '\\' {
+			if i+1 == len(s) {
+				return "", fmt.Errorf("regexp: %w: trailing backslash", ErrInvalidEscape)
+			}
+			i++
+			c = s[i]
+			if c >= utf8.RuneSelf || !unicode.IsPunct(rune(c)) && !unicode.IsSymbol(rune(c)) {
+				return "", fmt.Errorf("regexp: %w: \\%c is not a literal", ErrInvalidEscape, c)
+			}
+		} else if strings.IndexByte(`.+*?()|[]{}^$`, c) >= 0 {
+			return "", fmt.Errorf("regexp: %w: unescaped metacharacter %q", ErrInvalidEscape, c)
+		}
+		b.WriteByte(c)
+	}
+	return b.String(), nil
+}
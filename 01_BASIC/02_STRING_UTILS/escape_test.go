@@ -0,0 +1,151 @@
+package stringutils
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestEscapers(t *testing.T) {
+	tests := []struct {
+		name     string
+		fn       func(string) string
+		input    string
+		expected string
+	}{
+		{"EscapeHTML", EscapeHTML, `<a href="x">Tom & Jerry's</a>`, "&lt;a href=&#34;x&#34;&gt;Tom &amp; Jerry&#39;s&lt;/a&gt;"},
+		{"EscapeXML", EscapeXML, "<a b='c'>\"&\"\n</a>", "&lt;a b=&apos;c&apos;&gt;&quot;&amp;&quot;&#xA;&lt;/a&gt;"},
+		{"EscapeXML invalid char", EscapeXML, "a\x00b", "a�b"},
+		{"QuoteJSON", QuoteJSON, "say \"hi\"\n<b>\t", `"say \"hi\"\n\u003cb\u003e\t"`},
+		{"QuoteShell", QuoteShell, "it's $HOME", `'it'\''s $HOME'`},
+		{"QuoteShell empty", QuoteShell, "", "''"},
+		{"EscapeCSV plain", EscapeCSV, "plain", "plain"},
+		{"EscapeCSV comma", EscapeCSV, "a,b", `"a,b"`},
+		{"EscapeCSV quote", EscapeCSV, `say "hi"`, `"say ""hi"""`},
+		{"EscapeCSV leading space", EscapeCSV, " x", `" x"`},
+		{"QuoteSQL", QuoteSQL, "O'Brien", "'O''Brien'"},
+		{"QuoteSQL backslash", QuoteSQL, `a\b`, `'a\b'`},
+		{"EscapeRegexp", EscapeRegexp, "1+1=2? [yes]", `1\+1=2\? \[yes\]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.fn(tt.input); result != tt.expected {
+				t.Errorf("%s(%q) = %q; want %q", tt.name, tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUnescapers(t *testing.T) {
+	tests := []struct {
+		name     string
+		fn       func(string) (string, error)
+		input    string
+		expected string
+	}{
+		{"UnescapeXML", UnescapeXML, "&lt;&#233;&#xE9;&apos;&gt;", "<éé'>"},
+		{"UnquoteJSON", UnquoteJSON, `"aé\n"`, "aé\n"},
+		{"UnquoteShell", UnquoteShell, `'it'\''s'`, "it's"},
+		{"UnquoteShell escapes", UnquoteShell, `\$'HOME x'`, "$HOME x"},
+		{"UnescapeCSV", UnescapeCSV, `"say ""hi"""`, `say "hi"`},
+		{"UnescapeCSV plain", UnescapeCSV, "plain", "plain"},
+		{"UnquoteSQL", UnquoteSQL, "'O''Brien'", "O'Brien"},
+		{"UnescapeRegexp", UnescapeRegexp, `1\+1=2\?`, "1+1=2?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.fn(tt.input)
+			if err != nil || result != tt.expected {
+				t.Errorf("%s(%q) = %q, %v; want %q", tt.name, tt.input, result, err, tt.expected)
+			}
+		})
+	}
+
+	if result := UnescapeHTML("&lt;&eacute;&#233;&amp;"); result != "<éé&" {
+		t.Errorf("UnescapeHTML = %q; want %q", result, "<éé&")
+	}
+}
+
+func TestUnescapersReject(t *testing.T) {
+	tests := []struct {
+		name  string
+		fn    func(string) (string, error)
+		input string
+	}{
+		{"UnescapeXML unterminated", UnescapeXML, "a &amp b"},
+		{"UnescapeXML unknown entity", UnescapeXML, "&eacute;"},
+		{"UnescapeXML bad number", UnescapeXML, "&#xZZ;"},
+		{"UnquoteJSON not a string", UnquoteJSON, "null"},
+		{"UnquoteJSON bad escape", UnquoteJSON, `"\q"`},
+		{"UnquoteShell unterminated", UnquoteShell, "'abc"},
+		{"UnquoteShell unquoted", UnquoteShell, "'a' b"},
+		{"UnescapeCSV bare quote", UnescapeCSV, `a"b`},
+		{"UnescapeCSV unescaped quote", UnescapeCSV, `"a"b"`},
+		{"UnquoteSQL missing quotes", UnquoteSQL, "abc"},
+		{"UnquoteSQL unescaped quote", UnquoteSQL, "'it's'"},
+		{"UnescapeRegexp class", UnescapeRegexp, `\d+`},
+		{"UnescapeRegexp metacharacter", UnescapeRegexp, "a.b"},
+		{"UnescapeRegexp trailing backslash", UnescapeRegexp, `a\`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result, err := tt.fn(tt.input); !errors.Is(err, ErrInvalidEscape) {
+				t.Errorf("%s(%q) = %q, %v; want ErrInvalidEscape", tt.name, tt.input, result, err)
+			}
+		})
+	}
+}
+
+// roundTrips pairs each escaper with its unescaper and the inputs it guarantees to round-trip
+var roundTrips = []struct {
+	name     string
+	escape   func(string) string
+	unescape func(string) (string, error)
+	accepts  func(string) bool
+}{
+	{"HTML", EscapeHTML, func(s string) (string, error) { return UnescapeHTML(s), nil }, utf8.ValidString},
+	{"XML", EscapeXML, UnescapeXML, func(s string) bool {
+		return utf8.ValidString(s) && !strings.ContainsFunc(s, func(r rune) bool { return !isXMLChar(r) })
+	}},
+	{"JSON", QuoteJSON, UnquoteJSON, utf8.ValidString},
+	{"shell", QuoteShell, UnquoteShell, func(string) bool { return true }},
+	{"CSV", EscapeCSV, UnescapeCSV, func(string) bool { return true }},
+	{"SQL", QuoteSQL, UnquoteSQL, func(string) bool { return true }},
+	{"regexp", EscapeRegexp, UnescapeRegexp, func(string) bool { return true }},
+}
+
+func FuzzEscapeRoundTrip(f *testing.F) {
+	for _, seed := range []string{
+		"", "plain", `<a href="x">Tom & Jerry's</a>`, "it's", `say "hi"`, "a,b\r\nc",
+		"1+1=2? [yes] (no) {maybe} ^$|*.", `back\slash`, "ñandú 中文 🙂", "\t\n\x00\x7f", "&amp;&#233;",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, rt := range roundTrips {
+			if !rt.accepts(s) {
+				continue
+			}
+			escaped := rt.escape(s)
+			result, err := rt.unescape(escaped)
+			if err != nil || result != s {
+				t.Errorf("%s: unescape(%q) = %q, %v; want %q", rt.name, escaped, result, err, s)
+			}
+		}
+
+		if utf8.ValidString(s) {
+			re := regexp.MustCompile("^" + EscapeRegexp(s) + "$")
+			if !re.MatchString(s) {
+				t.Errorf("EscapeRegexp(%q) = %q does not match the input", s, EscapeRegexp(s))
+			}
+		}
+		if escaped := EscapeHTML(s); strings.ContainsAny(escaped, `<>"'`) {
+			t.Errorf("EscapeHTML(%q) = %q contains special characters", s, escaped)
+		}
+	})
+}
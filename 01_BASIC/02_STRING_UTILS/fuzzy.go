@@ -0,0 +1,324 @@
+package stringutils
+
+import (
+	"slices"
+	"unicode"
+	"unicode/utf8"
+)
+
+// stackRunes is the length up to which the distance functions keep their buffers on the stack
+const stackRunes = 64
+
+// appendRunes appends the runes of s to buf
+func appendRunes(buf []rune, s string) []rune {
+	for _, r := range s {
+		buf = append(buf, r)
+	}
+	return buf
+}
+
+// appendLowerRunes appends the lowercased runes of s to buf
+func appendLowerRunes(buf []rune, s string) []rune {
+	for _, r := range s {
+		buf = append(buf, unicode.ToLower(r))
+	}
+	return buf
+}
+
+// Levenshtein returns the edit distance between a and b: the minimum number of
+// rune insertions, deletions and substitutions that turn a into b
+func Levenshtein(a, b string) int {
+	var abuf, bbuf [stackRunes]rune
+	var row [stackRunes + 1]int
+	return levenshtein(appendRunes(abuf[:0], a), appendRunes(bbuf[:0], b), row[:0])
+}
+
+// levenshtein computes the distance with a single row of the dynamic programming table,
+// reusing the capacity of row
+func levenshtein(a, b []rune, row []int) int {
+	// A common prefix or suffix never changes the distance
+	for len(a) > 0 && len(b) > 0 && a[0] == b[0] {
+		a, b = a[1:], b[1:]
+	}
+	for len(a) > 0 && len(b) > 0 && a[len(a)-1] == b[len(b)-1] {
+		a, b = a[:len(a)-1], b[:len(b)-1]
+	}
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	if len(b) == 0 {
+		return len(a)
+	}
+
+	row = slices.Grow(row[:0], len(b)+1)[:len(b)+1]
+	for j := range row {
+		row[j] = j
+	}
+	for i, ra := range a {
+		diag := row[0]
+		row[0] = i + 1
+		for j, rb := range b {
+			cost := 1
+			if ra == rb {
+				cost = 0
+			}
+			diag, row[j+1] = row[j+1], min(row[j+1]+1, row[j]+1, diag+cost)
+		}
+	}
+	return row[len(b)]
+}
+
+// DamerauLevenshtein returns the edit distance between a and b counting the
+// transposition of two adjacent runes as one edit, so "ca" to "abc" is 2
+// Unlike the optimal string alignment distance, a substring may be edited again after a transposition
+func DamerauLevenshtein(a, b string) int {
+	var abuf, bbuf [stackRunes]rune
+	var dbuf [stackCells]int
+	var lbuf [stackRunes]int
+	return damerauLevenshtein(appendRunes(abuf[:0], a), appendRunes(bbuf[:0], b), dbuf[:0], lbuf[:0])
+}
+
+// stackCells is the size of the distance table DamerauLevenshtein keeps on the stack,
+// enough for two strings of about 30 runes
+const stackCells = 1024
+
+// damerauLevenshtein computes the distance with the full dynamic programming table,
+// reusing the capacity of d for the table and of last for the transposition lookup
+func damerauLevenshtein(ra, rb []rune, d, last []int) int {
+	n, m := len(ra), len(rb)
+	if n == 0 || m == 0 {
+		return n + m
+	}
+
+	// d[(i+1)*w+j+1] is the distance between ra[:i] and rb[:j]; the extra row and column hold a bound
+	w := m + 2
+	inf := n + m
+	d = slices.Grow(d[:0], (n+2)*w)[:(n+2)*w]
+	d[0] = inf
+	for i := 0; i <= n; i++ {
+		d[(i+1)*w] = inf
+		d[(i+1)*w+1] = i
+	}
+	for j := 0; j <= m; j++ {
+		d[j+1] = inf
+		d[w+j+1] = j
+	}
+
+	// last[j-1] is the last row of a, before the current one, whose rune equals rb[j-1]
+	last = slices.Grow(last[:0], m)[:m]
+	clear(last)
+	for i := 1; i <= n; i++ {
+		lastMatch := 0
+		for j := 1; j <= m; j++ {
+			i1, j1 := last[j-1], lastMatch
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+				lastMatch = j
+				last[j-1] = i
+			}
+			d[(i+1)*w+j+1] = min(
+				d[i*w+j]+cost,
+				d[(i+1)*w+j]+1,
+				d[i*w+j+1]+1,
+				d[i1*w+j1]+(i-i1-1)+1+(j-j1-1),
+			)
+		}
+	}
+	return d[(n+1)*w+m+1]
+}
+
+// Jaro returns the Jaro similarity of a and b, from 0 for no runes in common to 1 for equal strings
+func Jaro(a, b string) float64 {
+	var abuf, bbuf [stackRunes]rune
+	var flags [2 * stackRunes]bool
+	return jaro(appendRunes(abuf[:0], a), appendRunes(bbuf[:0], b), flags[:0])
+}
+
+// JaroWinkler returns the Jaro similarity of a and b boosted by the length of their
+// common prefix, up to 4 runes, which favors strings that differ only at the end
+func JaroWinkler(a, b string) float64 {
+	var abuf, bbuf [stackRunes]rune
+	var flags [2 * stackRunes]bool
+	return jaroWinkler(appendRunes(abuf[:0], a), appendRunes(bbuf[:0], b), flags[:0])
+}
+
+func jaroWinkler(a, b []rune, flags []bool) float64 {
+	sim := jaro(a, b, flags)
+	prefix := 0
+	for prefix < min(len(a), len(b), 4) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	return sim + float64(prefix)*0.1*(1-sim)
+}
+
+// jaro computes the Jaro similarity, reusing the capacity of flags to mark matched runes
+func jaro(a, b []rune, flags []bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	flags = slices.Grow(flags[:0], len(a)+len(b))[:len(a)+len(b)]
+	clear(flags)
+	matchedA, matchedB := flags[:len(a)], flags[len(a):]
+
+	// Runes match if they are equal and no further apart than half the longer string
+	window := max(max(len(a), len(b))/2-1, 0)
+	matches := 0
+	for i, r := range a {
+		for j := max(0, i-window); j < min(len(b), i+window+1); j++ {
+			if !matchedB[j] && b[j] == r {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	// Half the matched runes that appear in a different order are transpositions
+	transposed, k := 0, 0
+	for i := range a {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[k] {
+			k++
+		}
+		if a[i] != b[k] {
+			transposed++
+		}
+		k++
+	}
+
+	m := float64(matches)
+	return (m/float64(len(a)) + m/float64(len(b)) + (m-float64(transposed)/2)/m) / 3
+}
+
+// NGrams returns the overlapping sequences of n runes of s, e.g. the bigrams of "night"
+// are "ni", "ig", "gh" and "ht"; a string shorter than n is its own only n-gram,
+// and n less than 1 is treated as 1
+func NGrams(s string, n int) []string {
+	var grams []string
+	forEachNGram(s, n, func(g string) {
+		grams = append(grams, g)
+	})
+	return grams
+}
+
+// forEachNGram calls fn with each n-gram of s, slicing s without allocating
+func forEachNGram(s string, n int, fn func(string)) {
+	n = max(n, 1)
+	start, count := 0, 0
+	for end := 0; end < len(s); {
+		_, size := utf8.DecodeRuneInString(s[end:])
+		end += size
+		count++
+		if count > n {
+			_, size = utf8.DecodeRuneInString(s[start:])
+			start += size
+		}
+		if count >= n {
+			fn(s[start:end])
+		}
+	}
+	if count > 0 && count < n {
+		fn(s)
+	}
+}
+
+// JaccardSimilarity returns the Jaccard index of the sets of n-grams of a and b,
+// the size of their intersection divided by the size of their union
+// Two empty strings are equal and have similarity 1
+func JaccardSimilarity(a, b string, n int) float64 {
+	if a == "" && b == "" {
+		return 1
+	}
+	gramsA := make(map[string]bool)
+	forEachNGram(a, n, func(g string) {
+		gramsA[g] = true
+	})
+	union := len(gramsA)
+	common := 0
+	seen := make(map[string]bool)
+	forEachNGram(b, n, func(g string) {
+		if seen[g] {
+			return
+		}
+		seen[g] = true
+		if gramsA[g] {
+			common++
+		} else {
+			union++
+		}
+	})
+	return float64(common) / float64(union)
+}
+
+// FuzzyMatch is a candidate returned by FuzzyFind
+type FuzzyMatch struct {
+	Index int     // position in the haystack
+	Text  string  // the candidate itself
+	Score float64 // similarity to the query, from 0 to 1
+}
+
+// FuzzyFind returns the k candidates of haystack most similar to query, best first,
+// or all of them with a positive score if k <= 0
+// Matching ignores case; candidates score their Jaro-Winkler similarity to the query,
+// and those that contain the query score at least 0.9, more the shorter they are
+// Ties keep the order of the haystack; an empty query matches nothing
+func FuzzyFind(haystack []string, query string, k int) []FuzzyMatch {
+	if query == "" {
+		return nil
+	}
+	var qbuf, cbuf [stackRunes]rune
+	var flags [2 * stackRunes]bool
+	q := appendLowerRunes(qbuf[:0], query)
+	candidate := cbuf[:0]
+	scratch := flags[:0]
+
+	var matches []FuzzyMatch
+	for i, text := range haystack {
+		candidate = appendLowerRunes(candidate[:0], text)
+		// Grow the scratch space here so that jaro does not reallocate it for every long candidate
+		scratch = slices.Grow(scratch[:0], len(q)+len(candidate))
+		score := jaroWinkler(q, candidate, scratch)
+		if containsRunes(candidate, q) {
+			score = max(score, 0.9+0.1*float64(len(q))/float64(len(candidate)))
+		}
+		if score <= 0 {
+			continue
+		}
+
+		// Keep matches sorted by descending score, inserting after equal scores
+		pos, _ := slices.BinarySearchFunc(matches, score, func(m FuzzyMatch, s float64) int {
+			if m.Score >= s {
+				return -1
+			}
+			return 1
+		})
+		if k > 0 && pos >= k {
+			continue
+		}
+		matches = slices.Insert(matches, pos, FuzzyMatch{Index: i, Text: text, Score: score})
+		if k > 0 && len(matches) > k {
+			matches = matches[:k]
+		}
+	}
+	return matches
+}
+
+// containsRunes reports whether sub occurs in s
+func containsRunes(s, sub []rune) bool {
+	for i := 0; i+len(sub) <= len(s); i++ {
+		if slices.Equal(s[i:i+len(sub)], sub) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,177 @@
+package stringutils
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestEditDistances(t *testing.T) {
+	tests := []struct {
+		name        string
+		a, b        string
+		levenshtein int
+		damerau     int
+	}{
+		{"equal", "kitten", "kitten", 0, 0},
+		{"empty", "", "abc", 3, 3},
+		{"both empty", "", "", 0, 0},
+		{"kitten", "kitten", "sitting", 3, 3},
+		{"flaw", "flaw", "lawn", 2, 2},
+		{"transposition", "ab", "ba", 2, 1},
+		{"edit after transposition", "ca", "abc", 3, 2},
+		{"runes", "añño", "año", 1, 1},
+		{"emoji", "🙂🙃", "🙃🙂", 2, 1},
+		{"long", "the quick brown fox jumps over the lazy dog and keeps running far away",
+			"the quick brown fox jumped over the lazy dogs and kept running far away", 5, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := Levenshtein(tt.a, tt.b); result != tt.levenshtein {
+				t.Errorf("Levenshtein(%q, %q) = %d; want %d", tt.a, tt.b, result, tt.levenshtein)
+			}
+			if result := Levenshtein(tt.b, tt.a); result != tt.levenshtein {
+				t.Errorf("Levenshtein(%q, %q) = %d; want %d", tt.b, tt.a, result, tt.levenshtein)
+			}
+			if result := DamerauLevenshtein(tt.a, tt.b); result != tt.damerau {
+				t.Errorf("DamerauLevenshtein(%q, %q) = %d; want %d", tt.a, tt.b, result, tt.damerau)
+			}
+		})
+	}
+}
+
+func TestJaroWinkler(t *testing.T) {
+	tests := []struct {
+		a, b    string
+		jaro    float64
+		winkler float64
+	}{
+		{"MARTHA", "MARHTA", 0.944444, 0.961111},
+		{"DWAYNE", "DUANE", 0.822222, 0.840000},
+		{"DIXON", "DICKSONX", 0.766667, 0.813333},
+		{"abc", "xyz", 0, 0},
+		{"", "", 1, 1},
+		{"", "a", 0, 0},
+		{"crème", "crème", 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if result := Jaro(tt.a, tt.b); math.Abs(result-tt.jaro) > 1e-6 {
+				t.Errorf("Jaro(%q, %q) = %f; want %f", tt.a, tt.b, result, tt.jaro)
+			}
+			if result := JaroWinkler(tt.a, tt.b); math.Abs(result-tt.winkler) > 1e-6 {
+				t.Errorf("JaroWinkler(%q, %q) = %f; want %f", tt.a, tt.b, result, tt.winkler)
+			}
+		})
+	}
+}
+
+func TestNGrams(t *testing.T) {
+	tests := []struct {
+		input    string
+		n        int
+		expected []string
+	}{
+		{"night", 2, []string{"ni", "ig", "gh", "ht"}},
+		{"año", 2, []string{"añ", "ño"}},
+		{"ab", 3, []string{"ab"}},
+		{"ab", 0, []string{"a", "b"}},
+		{"", 2, nil},
+	}
+
+	for _, tt := range tests {
+		if result := NGrams(tt.input, tt.n); !slices.Equal(result, tt.expected) {
+			t.Errorf("NGrams(%q, %d) = %q; want %q", tt.input, tt.n, result, tt.expected)
+		}
+	}
+}
+
+func TestJaccardSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		n        int
+		expected float64
+	}{
+		{"night", "nacht", 2, 1.0 / 7},
+		{"context", "contact", 2, 3.0 / 9},
+		{"abc", "abc", 2, 1},
+		{"abc", "xyz", 1, 0},
+		{"", "", 2, 1},
+		{"", "a", 2, 0},
+	}
+
+	for _, tt := range tests {
+		if result := JaccardSimilarity(tt.a, tt.b, tt.n); math.Abs(result-tt.expected) > 1e-9 {
+			t.Errorf("JaccardSimilarity(%q, %q, %d) = %f; want %f", tt.a, tt.b, tt.n, result, tt.expected)
+		}
+	}
+}
+
+func TestFuzzyFind(t *testing.T) {
+	haystack := []string{"Banana", "Apple Pie", "apply", "Pineapple", "grape", "APPLE"}
+
+	tests := []struct {
+		name     string
+		query    string
+		k        int
+		expected []string
+	}{
+		{"best match first", "apple", 3, []string{"APPLE", "Apple Pie", "Pineapple"}},
+		{"typo", "aplpe", 1, []string{"APPLE"}},
+		{"empty query", "", 3, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var texts []string
+			for _, m := range FuzzyFind(haystack, tt.query, tt.k) {
+				texts = append(texts, m.Text)
+				if haystack[m.Index] != m.Text {
+					t.Errorf("match %q has index %d", m.Text, m.Index)
+				}
+			}
+			if !slices.Equal(texts, tt.expected) {
+				t.Errorf("FuzzyFind(%q, %d) = %q; want %q", tt.query, tt.k, texts, tt.expected)
+			}
+		})
+	}
+
+	all := FuzzyFind(haystack, "apple", 0)
+	if len(all) == 0 || len(all) > len(haystack) {
+		t.Fatalf("FuzzyFind with k = 0 returned %d matches", len(all))
+	}
+	for i := 1; i < len(all); i++ {
+		if all[i].Score > all[i-1].Score {
+			t.Errorf("matches not sorted by score: %v", all)
+		}
+	}
+}
+
+func TestFuzzyAllocations(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		Levenshtein("kitten", "sitting")
+		DamerauLevenshtein("kitten", "sitting")
+		JaroWinkler("MARTHA", "MARHTA")
+	})
+	if allocs != 0 {
+		t.Errorf("Levenshtein, DamerauLevenshtein and JaroWinkler allocate %v times for short strings; want 0", allocs)
+	}
+
+	// Buffers grown for long candidates are kept for the next ones
+	find := func(n int) func() {
+		haystack := make([]string, n)
+		for i := range haystack {
+			haystack[i] = strings.Repeat("long candidate ", 10) + strconv.Itoa(i)
+		}
+		return func() { FuzzyFind(haystack, strings.Repeat("long query ", 10), 1) }
+	}
+	small := testing.AllocsPerRun(10, find(10))
+	large := testing.AllocsPerRun(10, find(1000))
+	if large != small {
+		t.Errorf("FuzzyFind allocates %v times for 1000 candidates and %v for 10; want no per-candidate allocation", large, small)
+	}
+}
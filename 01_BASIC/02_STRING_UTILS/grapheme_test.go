@@ -0,0 +1,39 @@
+package stringutils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGraphemes(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{"empty string", "", nil},
+		{"ascii", "abc", []string{"a", "b", "c"}},
+		{"combining marks", "e\u0301\u0323x", []string{"e\u0301\u0323", "x"}},
+		{"crlf", "a\r\nb", []string{"a", "\r\n", "b"}},
+		{"lf cr", "\n\r", []string{"\n", "\r"}},
+		{"control before mark", "\t\u0301", []string{"\t", "\u0301"}},
+		{"hangul syllable", "한국", []string{"한", "국"}},
+		{"hangul jamo", "\u1100\u1161\u11A8\u1100", []string{"\u1100\u1161\u11A8", "\u1100"}},
+		{"emoji zwj", "👨\u200D👩\u200D👧x", []string{"👨\u200D👩\u200D👧", "x"}},
+		{"zwj without emoji", "a\u200Db", []string{"a\u200D", "b"}},
+		{"skin tone", "👋🏿👋", []string{"👋🏿", "👋"}},
+		{"flags", "🇯🇵🇺🇸🇬", []string{"🇯🇵", "🇺🇸", "🇬"}},
+		{"flag with zwj", "🏳\uFE0F\u200D🌈", []string{"🏳\uFE0F\u200D🌈"}},
+		{"spacing mark", "क\u093F", []string{"क\u093F"}},
+		{"prepend", "\u0600١", []string{"\u0600١"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Graphemes(tt.input)
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("Graphemes(%q) = %q; want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
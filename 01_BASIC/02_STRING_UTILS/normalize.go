@@ -0,0 +1,189 @@
+package stringutils
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// combiningRange is a run of runes sharing a canonical combining class
+type combiningRange struct {
+	lo, hi rune
+	class  uint8
+}
+
+// Hangul syllables are composed algorithmically from leading consonants (L),
+// vowels (V) and optional trailing consonants (T)
+const (
+	hangulBase   = 0xAC00
+	hangulLBase  = 0x1100
+	hangulVBase  = 0x1161
+	hangulTBase  = 0x11A7
+	hangulLCount = 19
+	hangulVCount = 21
+	hangulTCount = 28
+	hangulNCount = hangulVCount * hangulTCount
+	hangulCount  = hangulLCount * hangulNCount
+)
+
+// compositions is the inverse of decompositions for the pairs NFC recomposes
+var compositions = func() map[[2]rune]rune {
+	m := make(map[[2]rune]rune, len(decompositions))
+	for r, d := range decompositions {
+		if d[1] != 0 && !compositionExclusions[r] {
+			m[d] = r
+		}
+	}
+	return m
+}()
+
+// combiningClass returns the canonical combining class of r, 0 for starters
+func combiningClass(r rune) uint8 {
+	i, found := slices.BinarySearchFunc(combiningClasses, r, func(c combiningRange, r rune) int {
+		switch {
+		case c.hi < r:
+			return -1
+		case c.lo > r:
+			return 1
+		}
+		return 0
+	})
+	if !found {
+		return 0
+	}
+	return combiningClasses[i].class
+}
+
+// NFD returns s in Unicode Normalization Form D, with every precomposed character
+// decomposed and combining marks in canonical order, e.g. "é" becomes "é"
+func NFD(s string) string {
+	if isASCII(s) {
+		return s
+	}
+	return string(nfd(s))
+}
+
+// NFC returns s in Unicode Normalization Form C, the canonical decomposition recomposed
+// into precomposed characters where possible, e.g. "é" becomes "é"
+func NFC(s string) string {
+	if isASCII(s) {
+		return s
+	}
+	return string(compose(nfd(s)))
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+func nfd(s string) []rune {
+	out := make([]rune, 0, len(s))
+	for _, r := range s {
+		out = decompose(out, r)
+	}
+
+	// Canonical ordering: stable sort every run of combining marks by class
+	for i := 0; i < len(out); {
+		if combiningClass(out[i]) == 0 {
+			i++
+			continue
+		}
+		j := i
+		for j < len(out) && combiningClass(out[j]) != 0 {
+			j++
+		}
+		slices.SortStableFunc(out[i:j], func(a, b rune) int {
+			return int(combiningClass(a)) - int(combiningClass(b))
+		})
+		i = j
+	}
+	return out
+}
+
+// decompose appends the full canonical decomposition of r to out
+func decompose(out []rune, r rune) []rune {
+	if s := r - hangulBase; s >= 0 && s < hangulCount {
+		out = append(out, hangulLBase+s/hangulNCount, hangulVBase+(s%hangulNCount)/hangulTCount)
+		if t := s % hangulTCount; t != 0 {
+			out = append(out, hangulTBase+t)
+		}
+		return out
+	}
+	d, ok := decompositions[r]
+	if !ok {
+		return append(out, r)
+	}
+	out = decompose(out, d[0])
+	if d[1] != 0 {
+		out = decompose(out, d[1])
+	}
+	return out
+}
+
+// compose applies canonical composition to a decomposed, canonically ordered sequence
+func compose(runes []rune) []rune {
+	out := runes[:0]
+	starter := -1
+	var lastClass uint8
+	for _, r := range runes {
+		class := combiningClass(r)
+		if starter >= 0 {
+			// r is blocked from the starter by anything in between with class 0 or >= its own
+			adjacent := starter == len(out)-1
+			if adjacent || (lastClass != 0 && lastClass < class) {
+				if c, ok := composePair(out[starter], r); ok {
+					out[starter] = c
+					continue
+				}
+			}
+		}
+		if class == 0 {
+			starter = len(out)
+		}
+		lastClass = class
+		out = append(out, r)
+	}
+	return out
+}
+
+func composePair(a, b rune) (rune, bool) {
+	// Hangul L + V and LV + T
+	if l, v := a-hangulLBase, b-hangulVBase; l >= 0 && l < hangulLCount && v >= 0 && v < hangulVCount {
+		return hangulBase + (l*hangulVCount+v)*hangulTCount, true
+	}
+	if s, t := a-hangulBase, b-hangulTBase; s >= 0 && s < hangulCount && s%hangulTCount == 0 && t > 0 && t < hangulTCount {
+		return a + t, true
+	}
+	c, ok := compositions[[2]rune{a, b}]
+	return c, ok
+}
+
+// foldedLetters are letters with a stroke or other feature that has no canonical decomposition
+var foldedLetters = map[rune]rune{
+	'Ø': 'O', 'ø': 'o', 'Ł': 'L', 'ł': 'l', 'Đ': 'D', 'đ': 'd', 'Ħ': 'H', 'ħ': 'h', 'ı': 'i',
+}
+
+// FoldAccents removes diacritics from s, e.g. "Crème Brûlée" becomes "Creme Brulee"
+// and "Łódź" becomes "Lodz"; the result is in NFC
+func FoldAccents(s string) string {
+	if isASCII(s) {
+		return s
+	}
+	var b strings.Builder
+	for _, r := range nfd(s) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		if f, ok := foldedLetters[r]; ok {
+			r = f
+		}
+		b.WriteRune(r)
+	}
+	return NFC(b.String())
+}
@@ -0,0 +1,84 @@
+package stringutils
+
+import (
+	"testing"
+)
+
+func TestNormalization(t *testing.T) {
+	tests := []struct {
+		name string
+		nfc  string
+		nfd  string
+	}{
+		{"ascii", "hello", "hello"},
+		{"latin", "été", "e\u0301te\u0301"},
+		{"two marks", "ệ", "e\u0323\u0302"},
+		{"angstrom", "Å", "A\u030A"},
+		{"hangul", "한", "\u1112\u1161\u11AB"},
+		{"hangul lv", "가", "\u1100\u1161"},
+		{"greek", "ἄ", "α\u0313\u0301"},
+		{"excluded", "क\u093C", "क\u093C"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := NFD(tt.nfc); result != tt.nfd {
+				t.Errorf("NFD(%q) = %q; want %q", tt.nfc, result, tt.nfd)
+			}
+			if result := NFC(tt.nfd); result != tt.nfc {
+				t.Errorf("NFC(%q) = %q; want %q", tt.nfd, result, tt.nfc)
+			}
+		})
+	}
+}
+
+func TestNormalizationSpecialCases(t *testing.T) {
+	tests := []struct {
+		name     string
+		fn       func(string) string
+		input    string
+		expected string
+	}{
+		{"nfd reorders marks", NFD, "e\u0302\u0323", "e\u0323\u0302"},
+		{"nfc reorders marks", NFC, "e\u0302\u0323", "ệ"},
+		{"nfc singleton", NFC, "\u212B", "Å"},
+		{"nfc excluded stays decomposed", NFC, "\u0958", "क\u093C"},
+		{"nfc blocked mark", NFC, "a\u0301\u0301", "á\u0301"},
+		{"nfc mark before starter", NFC, "\u0301a", "\u0301a"},
+		{"nfc lvt", NFC, "\u1100\u1161\u11A8", "각"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.fn(tt.input); result != tt.expected {
+				t.Errorf("got %q; want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFoldAccents(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty string", "", ""},
+		{"ascii", "hello", "hello"},
+		{"french", "Crème Brûlée", "Creme Brulee"},
+		{"decomposed", "Cre\u0300me", "Creme"},
+		{"polish", "Łódź", "Lodz"},
+		{"danish", "Ø", "O"},
+		{"spanish", "año", "ano"},
+		{"greek", "ἄλφα", "αλφα"},
+		{"keeps spacing marks", "क\u093F", "क\u093F"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := FoldAccents(tt.input); result != tt.expected {
+				t.Errorf("FoldAccents(%q) = %q; want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package stringutils
+
+import (
+	"cmp"
+	"slices"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SearchOptions control how patterns are matched
+type SearchOptions struct {
+	// Overlapping reports every occurrence, so "aa" occurs twice in "aaa";
+	// otherwise matches are chosen leftmost first, preferring the longest, and never overlap
+	Overlapping bool
+	// IgnoreCase matches regardless of case using Unicode simple case folding
+	IgnoreCase bool
+}
+
+// Match is an occurrence of a pattern in a text
+type Match struct {
+	Pattern    int // index of the pattern that matched
+	Start, End int // byte offsets of the match in the text
+}
+
+// Matcher finds many patterns at once with an Aho–Corasick automaton, scanning the
+// text a single time however many patterns there are
+// It is built once with NewMatcher and can be reused concurrently
+type Matcher struct {
+	nodes   []acNode
+	lengths []int // length in runes of each pattern
+	longest int
+	opts    SearchOptions
+}
+
+// acNode is a state of the automaton: a prefix of one or more patterns
+type acNode struct {
+	next map[rune]int
+	fail int   // the state for the longest proper suffix that is also a prefix
+	out  []int // patterns that end in this state, including through failure links
+}
+
+// NewMatcher builds a Matcher for patterns
+// Empty patterns never match
+func NewMatcher(patterns []string, opts SearchOptions) *Matcher {
+	m := &Matcher{
+		nodes:   []acNode{{}},
+		lengths: make([]int, len(patterns)),
+		opts:    opts,
+	}
+	for i, p := range patterns {
+		if p == "" {
+			continue
+		}
+		state := 0
+		for _, r := range p {
+			r = m.fold(r)
+			next, ok := m.nodes[state].next[r]
+			if !ok {
+				if m.nodes[state].next == nil {
+					m.nodes[state].next = make(map[rune]int)
+				}
+				next = len(m.nodes)
+				m.nodes[state].next[r] = next
+				m.nodes = append(m.nodes, acNode{})
+			}
+			state = next
+			m.lengths[i]++
+		}
+		m.nodes[state].out = append(m.nodes[state].out, i)
+		m.longest = max(m.longest, m.lengths[i])
+	}
+
+	// Breadth-first, so the failure state of every node is computed before its children's
+	queue := []int{0}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		for r, child := range m.nodes[state].next {
+			queue = append(queue, child)
+			if state == 0 {
+				continue
+			}
+			fail := m.nodes[state].fail
+			for fail != 0 && m.nodes[fail].next[r] == 0 {
+				fail = m.nodes[fail].fail
+			}
+			if f, ok := m.nodes[fail].next[r]; ok {
+				fail = f
+			}
+			m.nodes[child].fail = fail
+			m.nodes[child].out = append(m.nodes[child].out, m.nodes[fail].out...)
+		}
+	}
+	return m
+}
+
+func (m *Matcher) fold(r rune) rune {
+	if !m.opts.IgnoreCase {
+		return r
+	}
+	return foldRune(r)
+}
+
+// foldRune maps every rune of a case folding orbit, such as k, K and the Kelvin sign, to the same rune
+func foldRune(r rune) rune {
+	if r < utf8.RuneSelf {
+		if 'a' <= r && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+		return r
+	}
+	folded := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		folded = min(folded, f)
+	}
+	return folded
+}
+
+// FindAll returns the matches of every pattern in text, ordered by position
+func (m *Matcher) FindAll(text string) []Match {
+	if m.longest == 0 {
+		return nil
+	}
+	// starts is a ring of the byte offsets of the last runes read, to locate where matches begin
+	starts := make([]int, m.longest)
+	var matches []Match
+	state := 0
+	n := 0
+	for i, r := range text {
+		starts[n%m.longest] = i
+		n++
+		r = m.fold(r)
+		for state != 0 && m.nodes[state].next[r] == 0 {
+			state = m.nodes[state].fail
+		}
+		state = m.nodes[state].next[r]
+
+		_, size := utf8.DecodeRuneInString(text[i:])
+		for _, p := range m.nodes[state].out {
+			start := starts[(n-m.lengths[p])%m.longest]
+			matches = append(matches, Match{Pattern: p, Start: start, End: i + size})
+		}
+	}
+	return arrangeMatches(matches, m.opts.Overlapping)
+}
+
+// Count returns the number of matches of every pattern in text
+func (m *Matcher) Count(text string) int {
+	return len(m.FindAll(text))
+}
+
+// arrangeMatches sorts matches by position, longest first, and drops overlapping
+// matches unless overlapping is set
+func arrangeMatches(matches []Match, overlapping bool) []Match {
+	slices.SortFunc(matches, func(a, b Match) int {
+		return cmp.Or(cmp.Compare(a.Start, b.Start), cmp.Compare(b.End, a.End), cmp.Compare(a.Pattern, b.Pattern))
+	})
+	if overlapping {
+		return matches
+	}
+	kept := matches[:0]
+	end := 0
+	for _, match := range matches {
+		if match.Start >= end {
+			kept = append(kept, match)
+			end = match.End
+		}
+	}
+	return kept
+}
+
+// FindAll returns the matches of pattern in text, ordered by position
+// Case-sensitive searches use Boyer–Moore–Horspool, which skips ahead by up to the pattern
+// length on a mismatch; IgnoreCase searches use a single-pattern Matcher
+func FindAll(text, pattern string, opts SearchOptions) []Match {
+	if pattern == "" {
+		return nil
+	}
+	if opts.IgnoreCase {
+		return NewMatcher([]string{pattern}, opts).FindAll(text)
+	}
+
+	// skip is how far the pattern can shift when the text byte under its last byte is c
+	var skip [256]int
+	last := len(pattern) - 1
+	for c := range skip {
+		skip[c] = len(pattern)
+	}
+	for i := range last {
+		skip[pattern[i]] = last - i
+	}
+
+	var matches []Match
+	for i := 0; i+last < len(text); {
+		if text[i+last] == pattern[last] && text[i:i+last] == pattern[:last] {
+			matches = append(matches, Match{Start: i, End: i + len(pattern)})
+			if !opts.Overlapping {
+				i += len(pattern)
+				continue
+			}
+		}
+		i += skip[text[i+last]]
+	}
+	return matches
+}
@@ -0,0 +1,136 @@
+package stringutils
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMatcher(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		opts     SearchOptions
+		text     string
+		expected []Match
+	}{
+		{
+			name:     "classic",
+			patterns: []string{"he", "she", "his", "hers"},
+			opts:     SearchOptions{Overlapping: true},
+			text:     "ushers",
+			expected: []Match{{1, 1, 4}, {3, 2, 6}, {0, 2, 4}},
+		},
+		{
+			name:     "non-overlapping prefers leftmost longest",
+			patterns: []string{"he", "she", "his", "hers"},
+			text:     "ushers",
+			expected: []Match{{1, 1, 4}},
+		},
+		{
+			name:     "overlapping repeats",
+			patterns: []string{"aa"},
+			opts:     SearchOptions{Overlapping: true},
+			text:     "aaaa",
+			expected: []Match{{0, 0, 2}, {0, 1, 3}, {0, 2, 4}},
+		},
+		{
+			name:     "non-overlapping repeats",
+			patterns: []string{"aa"},
+			text:     "aaaaa",
+			expected: []Match{{0, 0, 2}, {0, 2, 4}},
+		},
+		{
+			name:     "ignore case",
+			patterns: []string{"go", "gopher"},
+			opts:     SearchOptions{IgnoreCase: true},
+			text:     "GoPHER or GO",
+			expected: []Match{{1, 0, 6}, {0, 10, 12}},
+		},
+		{
+			name:     "ignore case changes byte length",
+			patterns: []string{"kelvin"},
+			opts:     SearchOptions{IgnoreCase: true},
+			text:     "0 Kelvin",
+			expected: []Match{{0, 2, 10}},
+		},
+		{
+			name:     "unicode",
+			patterns: []string{"ñu", "año"},
+			opts:     SearchOptions{IgnoreCase: true},
+			text:     "El AÑO del Ñu",
+			expected: []Match{{1, 3, 7}, {0, 12, 15}},
+		},
+		{
+			name:     "empty pattern",
+			patterns: []string{"", "b"},
+			text:     "abc",
+			expected: []Match{{1, 1, 2}},
+		},
+		{
+			name:     "no match",
+			patterns: []string{"x", "yz"},
+			text:     "abc",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMatcher(tt.patterns, tt.opts)
+			result := m.FindAll(tt.text)
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("FindAll(%q) = %v; want %v", tt.text, result, tt.expected)
+			}
+			if count := m.Count(tt.text); count != len(tt.expected) {
+				t.Errorf("Count(%q) = %d; want %d", tt.text, count, len(tt.expected))
+			}
+		})
+	}
+}
+
+func TestFindAll(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		pattern  string
+		opts     SearchOptions
+		expected []int
+	}{
+		{"single", "hello world", "world", SearchOptions{}, []int{6}},
+		{"repeated", "abcabcabc", "abc", SearchOptions{}, []int{0, 3, 6}},
+		{"overlapping", "aaaa", "aa", SearchOptions{Overlapping: true}, []int{0, 1, 2}},
+		{"non-overlapping", "aaaa", "aa", SearchOptions{}, []int{0, 2}},
+		{"ignore case", "Go go GO", "go", SearchOptions{IgnoreCase: true}, []int{0, 3, 6}},
+		{"unicode", "café, café", "é", SearchOptions{}, []int{3, 10}},
+		{"longer than text", "ab", "abc", SearchOptions{}, nil},
+		{"empty pattern", "abc", "", SearchOptions{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var starts []int
+			for _, m := range FindAll(tt.text, tt.pattern, tt.opts) {
+				starts = append(starts, m.Start)
+				if m.End-m.Start != len(tt.pattern) {
+					t.Errorf("match at %d ends at %d", m.Start, m.End)
+				}
+			}
+			if !slices.Equal(starts, tt.expected) {
+				t.Errorf("FindAll(%q, %q) starts = %v; want %v", tt.text, tt.pattern, starts, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFindAllAgreesWithCount(t *testing.T) {
+	text := strings.Repeat("the cat sat on the mat with the other cat ", 20)
+	for _, pattern := range []string{"the", "cat", "at", " ", "t", "mat with"} {
+		if got, want := len(FindAll(text, pattern, SearchOptions{})), CountOccurrences(text, pattern); got != want {
+			t.Errorf("FindAll(%q) found %d matches; CountOccurrences found %d", pattern, got, want)
+		}
+		if got, want := NewMatcher([]string{pattern}, SearchOptions{}).Count(text), CountOccurrences(text, pattern); got != want {
+			t.Errorf("Matcher.Count(%q) = %d; CountOccurrences found %d", pattern, got, want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package stringutils
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Slugger builds URL slugs
+// The zero value joins words with "-" and has no length limit
+type Slugger struct {
+	// Separator joins the words of the slug, "-" if empty
+	Separator string
+	// MaxLength limits the slug in bytes, cutting at a word boundary; 0 means no limit
+	// A deduplicated slug too short to hold any of the words before its suffix is just the
+	// number, e.g. "2", and it is empty if not even the number fits
+	MaxLength int
+	// Existing holds the slugs already taken; if it is not nil, a slug that is taken gets a
+	// numeric suffix such as "-2", and every slug returned is added to it
+	Existing map[string]bool
+}
+
+// Slugify converts s to a lowercase ASCII slug with words joined by hyphens,
+// e.g. "¡Hola, Señor Müller!" becomes "hola-senor-muller"
+func Slugify(s string) string {
+	return Slugger{}.Slug(s)
+}
+
+// Slug converts s to a slug: transliterated to lowercase ASCII, with every run of
+// characters other than letters and digits collapsed into one separator
+func (sl Slugger) Slug(s string) string {
+	sep := sl.Separator
+	if sep == "" {
+		sep = "-"
+	}
+	// Apostrophes join the parts of a word: "don't" becomes "dont"
+	s = strings.ReplaceAll(strings.ToLower(Transliterate(s)), "'", "")
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9')
+	})
+
+	slug := joinWithin(words, sep, sl.MaxLength)
+	if sl.Existing == nil || slug == "" {
+		return slug
+	}
+	for n := 2; sl.Existing[slug]; n++ {
+		number := strconv.Itoa(n)
+		suffix := sep + number
+		switch {
+		case sl.MaxLength <= 0:
+			slug = joinWithin(words, sep, 0) + suffix
+		case sl.MaxLength > len(suffix):
+			slug = joinWithin(words, sep, sl.MaxLength-len(suffix)) + suffix
+		case sl.MaxLength >= len(number):
+			// No room for any of the words, so the number stands alone
+			slug = number
+		default:
+			// Later numbers are no shorter, so no unique slug fits
+			return ""
+		}
+	}
+	sl.Existing[slug] = true
+	return slug
+}
+
+// joinWithin joins as many leading words as fit in limit bytes, or all of them if limit is 0
+// A first word longer than limit is cut
+func joinWithin(words []string, sep string, limit int) string {
+	joined := strings.Join(words, sep)
+	if limit <= 0 || len(joined) <= limit {
+		return joined
+	}
+	if len(words[0]) >= limit {
+		return words[0][:limit]
+	}
+	n := len(words[0])
+	count := 1
+	for _, w := range words[1:] {
+		if n+len(sep)+len(w) > limit {
+			break
+		}
+		n += len(sep) + len(w)
+		count++
+	}
+	return strings.Join(words[:count], sep)
+}
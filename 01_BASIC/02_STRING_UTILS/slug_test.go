@@ -0,0 +1,89 @@
+package stringutils
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Hello World", "hello-world"},
+		{"¡Hola, Señor Müller!", "hola-senor-muller"},
+		{"  --Multiple   separators__here--  ", "multiple-separators-here"},
+		{"Don't stop", "dont-stop"},
+		{"Straße in Москва", "strasse-in-moskva"},
+		{"Ελληνικά 2024", "ellinika-2024"},
+		{"日本", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if result := Slugify(tt.input); result != tt.expected {
+				t.Errorf("Slugify(%q) = %q; want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSlugger(t *testing.T) {
+	tests := []struct {
+		name     string
+		slugger  Slugger
+		input    string
+		expected string
+	}{
+		{"separator", Slugger{Separator: "_"}, "Hello World", "hello_world"},
+		{"max length at word boundary", Slugger{MaxLength: 15}, "The quick brown fox", "the-quick-brown"},
+		{"max length drops partial word", Slugger{MaxLength: 12}, "The quick brown fox", "the-quick"},
+		{"max length cuts long word", Slugger{MaxLength: 5}, "Supercalifragilistic", "super"},
+		{"fits exactly", Slugger{MaxLength: 11}, "hello world", "hello-world"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.slugger.Slug(tt.input); result != tt.expected {
+				t.Errorf("Slug(%q) = %q; want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSluggerDeduplicates(t *testing.T) {
+	sl := Slugger{Existing: map[string]bool{"hello-world": true}}
+	for _, expected := range []string{"hello-world-2", "hello-world-3", "hello-world-4"} {
+		if result := sl.Slug("Hello World"); result != expected {
+			t.Errorf("Slug(%q) = %q; want %q", "Hello World", result, expected)
+		}
+	}
+	if result := sl.Slug("Other"); result != "other" {
+		t.Errorf("Slug(%q) = %q; want %q", "Other", result, "other")
+	}
+
+	limited := Slugger{MaxLength: 11, Existing: map[string]bool{}}
+	for _, expected := range []string{"hello-world", "hello-2", "hello-3"} {
+		if result := limited.Slug("Hello World"); result != expected {
+			t.Errorf("Slug(%q) with MaxLength 11 = %q; want %q", "Hello World", result, expected)
+		}
+	}
+
+	// Without room for the word and the suffix, the number is used alone
+	tiny := Slugger{MaxLength: 2, Existing: map[string]bool{}}
+	for _, expected := range []string{"he", "2", "3"} {
+		if result := tiny.Slug("Hello"); result != expected {
+			t.Errorf("Slug(%q) with MaxLength 2 = %q; want %q", "Hello", result, expected)
+		}
+	}
+
+	// Once the numbers no longer fit, no unique slug does
+	single := Slugger{MaxLength: 1, Existing: map[string]bool{"h": true}}
+	for n := 2; n <= 9; n++ {
+		single.Existing[strconv.Itoa(n)] = true
+	}
+	if result := single.Slug("Hello"); result != "" {
+		t.Errorf("Slug(%q) with MaxLength 1 and every digit taken = %q; want \"\"", "Hello", result)
+	}
+}
@@ -2,35 +2,65 @@ package stringutils
 
 import (
 	"strings"
+	"unicode"
 )
 
 // Reverse reverses a string
+// Grapheme clusters are kept intact, so combining marks, emoji sequences and flags survive
 func Reverse(s string) string {
-	// TODO: Implement this function
-	return ""
+	clusters := Graphemes(s)
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := len(clusters) - 1; i >= 0; i-- {
+		b.WriteString(clusters[i])
+	}
+	return b.String()
 }
 
 // IsPalindrome checks if a string is a palindrome
-// Ignores case and spaces
+// Ignores case, accents, spaces and punctuation, and compares normalized grapheme clusters,
+// so "Anita lava la tina" and "Dábale arroz a la zorra el abad" are palindromes
 func IsPalindrome(s string) bool {
-	// TODO: Implement this function
-	return false
+	var clusters []string
+	for _, c := range Graphemes(strings.ToLower(FoldAccents(s))) {
+		r := []rune(c)[0]
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			clusters = append(clusters, c)
+		}
+	}
+	for i, j := 0, len(clusters)-1; i < j; i, j = i+1, j-1 {
+		if clusters[i] != clusters[j] {
+			return false
+		}
+	}
+	return true
 }
 
 // CountOccurrences counts the occurrences of a substring in a string
+// Overlapping occurrences are not counted, and an empty substring occurs 0 times
 func CountOccurrences(s, substr string) int {
-	// TODO: Implement this function
-	return 0
+	if substr == "" {
+		return 0
+	}
+	return strings.Count(s, substr)
 }
 
 // ToTitleCase converts the first letter of each word to uppercase
+// The rest of each word is lowercased; TitleCaser handles style guides, locales and acronyms
 func ToTitleCase(s string) string {
-	// TODO: Implement this function
-	return ""
+	runes := []rune(s)
+	for i, r := range runes {
+		if i == 0 || runes[i-1] == ' ' {
+			runes[i] = unicode.ToTitle(r)
+		} else {
+			runes[i] = unicode.ToLower(r)
+		}
+	}
+	return string(runes)
 }
 
 // RemoveDuplicateChars removes duplicate characters from a string
+// The first occurrence of each character is kept; RemoveDuplicates offers other options
 func RemoveDuplicateChars(s string) string {
-	// TODO: Implement this function
-	return ""
-}
\ No newline at end of file
+	return RemoveDuplicates(s, DedupOptions{})
+}
@@ -16,6 +16,12 @@ func TestReverse(t *testing.T) {
 		{"with spaces", "hello world", "dlrow olleh"},
 		{"with special chars", "a,b$c!", "!c$b,a"},
 		{"with numbers", "abc123", "321cba"},
+		{"combining marks", "cafe\u0301 ole\u0301", "e\u0301lo e\u0301fac"},
+		{"emoji zwj sequence", "hi👩\u200D💻!", "!👩\u200D💻ih"},
+		{"flags", "🇪🇸🇫🇷", "🇫🇷🇪🇸"},
+		{"skin tone", "a👍🏽b", "b👍🏽a"},
+		{"hangul jamo", "\u1100\u1161\u11A8x", "x\u1100\u1161\u11A8"},
+		{"crlf", "a\r\nb", "b\r\na"},
 	}
 
 	for _, tt := range tests {
@@ -41,7 +47,15 @@ func TestIsPalindrome(t *testing.T) {
 		{"palindrome with spaces", "a man a plan a canal panama", true},
 		{"palindrome with punctuation", "A man, a plan, a canal: Panama", true},
 		{"non-palindrome", "hello", false},
-		{"almost palindrome", "almosttsomla", false},
+		{"almost palindrome", "almosttosmla", false},
+		{"accents", "Dábale arroz a la zorra el abad", true},
+		{"composed and decomposed", "e\u0301te\u0301", true},
+		{"mixed normalization", "\u00e9t\u00e9", true},
+		{"symbols and digits", "¡1-2-1!", true},
+		{"non-latin", "А роза упала на лапу Азора", true},
+		{"different accents still match", "Óto", true},
+		{"accent on one end only", "otó", true},
+		{"emoji are not letters", "ab👍ba", true},
 	}
 
 	for _, tt := range tests {
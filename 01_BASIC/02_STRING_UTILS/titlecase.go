@@ -0,0 +1,221 @@
+package stringutils
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TitleStyle selects the style guide a TitleCaser follows for minor words
+type TitleStyle int
+
+const (
+	// StyleBasic capitalizes every word
+	StyleBasic TitleStyle = iota
+	// StyleAP follows the Associated Press Stylebook: articles, coordinating conjunctions
+	// and prepositions of up to three letters stay lowercase
+	StyleAP
+	// StyleChicago follows the Chicago Manual of Style: articles, prepositions of any length
+	// and the conjunctions and, but, for, nor and or stay lowercase
+	StyleChicago
+	// StyleAPA follows the APA style: articles, conjunctions and prepositions of up to
+	// three letters stay lowercase
+	StyleAPA
+)
+
+var (
+	articles = []string{"a", "an", "the"}
+
+	apMinorWords = wordSet(articles, []string{
+		"and", "but", "for", "nor", "or", "so", "yet",
+		"as", "at", "by", "in", "of", "off", "on", "out", "per", "to", "up", "via",
+	})
+
+	chicagoMinorWords = wordSet(articles, []string{
+		"and", "but", "for", "nor", "or", "as", "to",
+		"about", "above", "across", "after", "against", "along", "amid", "among", "around", "at",
+		"before", "behind", "below", "beneath", "beside", "besides", "between", "beyond", "by",
+		"despite", "down", "during", "except", "from", "in", "inside", "into", "like", "near",
+		"of", "off", "on", "onto", "opposite", "out", "outside", "over", "past", "per", "round",
+		"since", "than", "through", "throughout", "till", "toward", "towards", "under",
+		"underneath", "unlike", "until", "up", "upon", "versus", "via", "with", "within", "without",
+	})
+
+	apaMinorWords = wordSet(articles, []string{
+		"and", "as", "but", "for", "if", "nor", "or", "so", "yet",
+		"at", "by", "in", "of", "off", "on", "per", "to", "up", "via",
+	})
+)
+
+func wordSet(lists ...[]string) map[string]bool {
+	set := make(map[string]bool)
+	for _, list := range lists {
+		for _, w := range list {
+			set[w] = true
+		}
+	}
+	return set
+}
+
+// TitleCaser converts strings to title case following a style guide and a locale
+// The zero value capitalizes every word with the default Unicode casing rules
+type TitleCaser struct {
+	Style TitleStyle
+	// Locale selects language-specific casing: "tr" and "az" map i to İ and I to ı,
+	// and "nl" capitalizes the digraph ij as IJ; anything else uses the default rules
+	Locale string
+}
+
+// Title converts s to title case
+// The first and last words, and the first word after a colon or a sentence, are always capitalized;
+// each part of a hyphenated word is cased on its own; acronyms such as "NASA" and
+// mixed-case words such as "iPhone" are kept as written unless the whole string is uppercase
+func (c TitleCaser) Title(s string) string {
+	if !strings.ContainsFunc(s, unicode.IsLower) {
+		s = c.lower(s)
+	}
+
+	tokens := splitWords(s)
+	last := -1
+	for i, tok := range tokens {
+		if strings.ContainsFunc(tok, isWordRune) {
+			last = i
+		}
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	first := true
+	for i, tok := range tokens {
+		if !strings.ContainsFunc(tok, isWordRune) {
+			b.WriteString(tok)
+			continue
+		}
+		b.WriteString(c.titleWord(tok, first, i == last))
+		// A colon or the end of a sentence starts a new title, as in "Star Wars: The Empire Strikes Back"
+		first = endsTitle(tok)
+	}
+	return b.String()
+}
+
+// endsTitle reports whether the word ends with a colon or the end of a sentence
+// Words are split at whitespace, so a final period is always followed by it; the period
+// of a dotted abbreviation such as "U.S." or "e.g." does not end the sentence
+func endsTitle(word string) bool {
+	if strings.ContainsAny(word[len(word)-1:], ":?!") {
+		return true
+	}
+	body, ok := strings.CutSuffix(word, ".")
+	return ok && !strings.Contains(body, ".")
+}
+
+// splitWords splits s into alternating runs of whitespace and non-whitespace
+func splitWords(s string) []string {
+	var tokens []string
+	start := 0
+	for i, r := range s {
+		if first, _ := utf8.DecodeRuneInString(s[start:]); i > start && unicode.IsSpace(r) != unicode.IsSpace(first) {
+			tokens = append(tokens, s[start:i])
+			start = i
+		}
+	}
+	if start < len(s) {
+		tokens = append(tokens, s[start:])
+	}
+	return tokens
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
+}
+
+// titleWord cases a whitespace-delimited word, treating each hyphenated part as a word
+func (c TitleCaser) titleWord(word string, first, last bool) string {
+	parts := strings.Split(word, "-")
+	for i, part := range parts {
+		parts[i] = c.titlePart(part, (first && i == 0) || (last && i == len(parts)-1))
+	}
+	return strings.Join(parts, "-")
+}
+
+func (c TitleCaser) titlePart(part string, force bool) string {
+	start := strings.IndexFunc(part, isWordRune)
+	if start < 0 {
+		return part
+	}
+	end := strings.LastIndexFunc(part, isWordRune)
+	_, size := utf8.DecodeRuneInString(part[end:])
+	prefix, core, suffix := part[:start], part[start:end+size], part[end+size:]
+
+	if isAcronym(core) || isMixedCase(core) {
+		return part
+	}
+	lowered := c.lower(core)
+	if !force && c.isMinor(lowered) {
+		return prefix + lowered + suffix
+	}
+	return prefix + c.capitalize(lowered) + suffix
+}
+
+// isAcronym reports whether word has at least two letters and all of them are uppercase, e.g. "NASA" or "U.S"
+func isAcronym(word string) bool {
+	letters := 0
+	for _, r := range word {
+		if unicode.IsLower(r) {
+			return false
+		}
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+	return letters >= 2
+}
+
+// isMixedCase reports whether word has an uppercase letter after a lowercase one, e.g. "iPhone" or "McDonald"
+func isMixedCase(word string) bool {
+	sawLower := false
+	for _, r := range word {
+		if sawLower && unicode.IsUpper(r) {
+			return true
+		}
+		sawLower = sawLower || unicode.IsLower(r)
+	}
+	return false
+}
+
+func (c TitleCaser) isMinor(word string) bool {
+	switch c.Style {
+	case StyleAP:
+		return apMinorWords[word]
+	case StyleChicago:
+		return chicagoMinorWords[word]
+	case StyleAPA:
+		return apaMinorWords[word]
+	}
+	return false
+}
+
+func (c TitleCaser) turkic() bool {
+	return c.Locale == "tr" || c.Locale == "az"
+}
+
+func (c TitleCaser) lower(s string) string {
+	if c.turkic() {
+		return strings.ToLowerSpecial(unicode.TurkishCase, s)
+	}
+	return strings.ToLower(s)
+}
+
+// capitalize converts the first letter of a lowercase word to title case
+func (c TitleCaser) capitalize(word string) string {
+	if c.Locale == "nl" && strings.HasPrefix(word, "ij") {
+		return "IJ" + word[2:]
+	}
+	r, size := utf8.DecodeRuneInString(word)
+	if c.turkic() {
+		r = unicode.TurkishCase.ToTitle(r)
+	} else {
+		r = unicode.ToTitle(r)
+	}
+	return string(r) + word[size:]
+}
@@ -0,0 +1,54 @@
+package stringutils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTitleCaser(t *testing.T) {
+	tests := []struct {
+		name     string
+		caser    TitleCaser
+		input    string
+		expected string
+	}{
+		{"basic", TitleCaser{}, "the lord of the rings", "The Lord Of The Rings"},
+		{"ap minor words", TitleCaser{Style: StyleAP}, "the lord of the rings", "The Lord of the Rings"},
+		{"ap long preposition", TitleCaser{Style: StyleAP}, "gone with the wind", "Gone With the Wind"},
+		{"chicago long preposition", TitleCaser{Style: StyleChicago}, "gone with the wind", "Gone with the Wind"},
+		{"apa long preposition", TitleCaser{Style: StyleAPA}, "gone with the wind", "Gone With the Wind"},
+		{"last word", TitleCaser{Style: StyleChicago}, "what is this all about", "What Is This All About"},
+		{"after colon", TitleCaser{Style: StyleAP}, "star wars: the empire strikes back", "Star Wars: The Empire Strikes Back"},
+		{"hyphenated", TitleCaser{Style: StyleChicago}, "a step-by-step guide to self-driving cars", "A Step-by-Step Guide to Self-Driving Cars"},
+		{"acronyms and mixed case", TitleCaser{Style: StyleAP}, "my new iPhone and the NASA budget", "My New iPhone and the NASA Budget"},
+		{"dotted acronym", TitleCaser{Style: StyleAP}, "a history of the U.S. navy", "A History of the U.S. Navy"},
+		{"abbreviation mid-sentence", TitleCaser{Style: StyleAP}, "made in the U.S. and abroad", "Made in the U.S. and Abroad"},
+		{"after sentence", TitleCaser{Style: StyleAP}, "the end. and a new start", "The End. And a New Start"},
+		{"all uppercase", TitleCaser{Style: StyleAP}, "THE LORD OF THE RINGS", "The Lord of the Rings"},
+		{"punctuation", TitleCaser{Style: StyleAP}, "\"the end\" (of an era)", "\"The End\" (of an Era)"},
+		{"spacing", TitleCaser{}, "  hello   world ", "  Hello   World "},
+		{"turkish", TitleCaser{Locale: "tr"}, "istanbul ile izmir", "İstanbul İle İzmir"},
+		{"turkish uppercase", TitleCaser{Locale: "tr"}, "IŞIK", "Işık"},
+		{"azerbaijani", TitleCaser{Locale: "az"}, "iki il", "İki İl"},
+		{"default i", TitleCaser{}, "istanbul", "Istanbul"},
+		{"dutch", TitleCaser{Locale: "nl"}, "het ijsselmeer", "Het IJsselmeer"},
+		{"digraph", TitleCaser{}, "ǆemal", "ǅemal"},
+		{"empty", TitleCaser{Style: StyleAP}, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.caser.Title(tt.input); result != tt.expected {
+				t.Errorf("Title(%q) = %q; want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSplitWords(t *testing.T) {
+	input := "war\u00a0\u00a0of\u3000the  worlds"
+	expected := []string{"war", "\u00a0\u00a0", "of", "\u3000", "the", "  ", "worlds"}
+	if result := splitWords(input); !slices.Equal(result, expected) {
+		t.Errorf("splitWords(%q) = %q; want %q", input, result, expected)
+	}
+}
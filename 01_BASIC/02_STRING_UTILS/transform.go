@@ -0,0 +1,184 @@
+package stringutils
+
+import (
+	"errors"
+	"io"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	// ErrShortDst is returned by Transform when dst is too small for the output
+	ErrShortDst = errors.New("transform: short destination buffer")
+	// ErrShortSrc is returned by Transform when src ends in the middle of a character
+	// and more input is needed to continue
+	ErrShortSrc = errors.New("transform: short source buffer")
+)
+
+// Transformer transforms a stream of bytes in chunks, with the same contract as
+// golang.org/x/text/transform.Transformer
+type Transformer interface {
+	// Transform writes to dst the transformed bytes of src, returning how many bytes of each
+	// it used; atEOF reports that src is the end of the input
+	// It returns ErrShortDst if dst has no room for more output and ErrShortSrc if src
+	// ends with an incomplete character, having made what progress it could
+	Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error)
+	// Reset clears the state so the Transformer can start a new stream
+	Reset()
+}
+
+// runeTransformer is a Transformer that maps each rune of the input to at most one rune
+// of output through a stateful step function
+type runeTransformer struct {
+	// step returns the rune to write for r, if any
+	step func(r rune) (rune, bool)
+	// flush returns a rune held back for the end of the input, if any; it may be nil
+	flush func() (rune, bool)
+	reset func()
+}
+
+func (t *runeTransformer) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	for nSrc < len(src) {
+		if !atEOF && !utf8.FullRune(src[nSrc:]) {
+			return nDst, nSrc, ErrShortSrc
+		}
+		if len(dst)-nDst < utf8.UTFMax {
+			return nDst, nSrc, ErrShortDst
+		}
+		r, size := utf8.DecodeRune(src[nSrc:])
+		if out, ok := t.step(r); ok {
+			nDst += utf8.EncodeRune(dst[nDst:], out)
+		}
+		nSrc += size
+	}
+	if atEOF && t.flush != nil {
+		if len(dst)-nDst < utf8.UTFMax {
+			return nDst, nSrc, ErrShortDst
+		}
+		if out, ok := t.flush(); ok {
+			nDst += utf8.EncodeRune(dst[nDst:], out)
+		}
+	}
+	return nDst, nSrc, nil
+}
+
+func (t *runeTransformer) Reset() {
+	t.reset()
+}
+
+// NewTitleCaseTransformer returns a Transformer that converts a stream as ToTitleCase does
+func NewTitleCaseTransformer() Transformer {
+	afterSpace := true
+	return &runeTransformer{
+		step: func(r rune) (rune, bool) {
+			wordStart := afterSpace
+			afterSpace = r == ' '
+			if wordStart {
+				return unicode.ToTitle(r), true
+			}
+			return unicode.ToLower(r), true
+		},
+		reset: func() {
+			afterSpace = true
+		},
+	}
+}
+
+// TransformString applies t to s from the start of a stream
+func TransformString(t Transformer, s string) (string, error) {
+	t.Reset()
+	src := []byte(s)
+	dst := make([]byte, len(s)+utf8.UTFMax)
+	var out []byte
+	for {
+		nDst, nSrc, err := t.Transform(dst, src, true)
+		out = append(out, dst[:nDst]...)
+		src = src[nSrc:]
+		switch {
+		case err == nil:
+			return string(out), nil
+		case !errors.Is(err, ErrShortDst):
+			return string(out), err
+		case nDst == 0 && nSrc == 0:
+			dst = make([]byte, 2*len(dst))
+		}
+	}
+}
+
+// transformReader is the io.Reader returned by NewReader
+type transformReader struct {
+	r       io.Reader
+	t       Transformer
+	readErr error // error from r, io.EOF at the end of the input
+	err     error // error to return once dst is drained
+	done    bool
+
+	src        []byte
+	src0, src1 int // unread input is src[src0:src1]
+	dst        []byte
+	dst0, dst1 int // transformed output not yet returned is dst[dst0:dst1]
+}
+
+const transformBufferSize = 4096
+
+// NewReader returns a reader of the contents of r transformed by t, which is reset first,
+// so transforms can be applied to streams of any size in constant memory
+func NewReader(r io.Reader, t Transformer) io.Reader {
+	t.Reset()
+	return &transformReader{
+		r:   r,
+		t:   t,
+		src: make([]byte, transformBufferSize),
+		dst: make([]byte, transformBufferSize),
+	}
+}
+
+func (r *transformReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	for {
+		if r.dst0 != r.dst1 {
+			n := copy(p, r.dst[r.dst0:r.dst1])
+			r.dst0 += n
+			return n, nil
+		}
+		if r.done {
+			return 0, r.err
+		}
+
+		if r.src0 != r.src1 || r.readErr != nil {
+			atEOF := r.readErr != nil
+			nDst, nSrc, err := r.t.Transform(r.dst, r.src[r.src0:r.src1], atEOF)
+			r.dst0, r.dst1 = 0, nDst
+			r.src0 += nSrc
+			switch {
+			case err == nil:
+				if atEOF {
+					r.done, r.err = true, r.readErr
+				}
+				if nDst > 0 || atEOF {
+					continue
+				}
+			case errors.Is(err, ErrShortDst) && (nDst > 0 || nSrc > 0):
+				continue
+			case errors.Is(err, ErrShortSrc) && !atEOF && r.src1-r.src0 < len(r.src):
+				if nDst > 0 {
+					continue
+				}
+			default:
+				r.done, r.err = true, err
+				continue
+			}
+		}
+
+		// Move the unread input to the front and read more after it
+		r.src1 = copy(r.src, r.src[r.src0:r.src1])
+		r.src0 = 0
+		n, err := r.r.Read(r.src[r.src1:])
+		r.src1 += n
+		if err != nil {
+			r.readErr = err
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package stringutils
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestTransformString(t *testing.T) {
+	dedup, err := NewDedupTransformer(DedupOptions{Adjacent: true, KeepLast: true, IgnoreCase: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		transformer Transformer
+		input       string
+		expected    string
+	}{
+		{"title case", NewTitleCaseTransformer(), "hELLO wORLD", "Hello World"},
+		{"title case unicode", NewTitleCaseTransformer(), "ñandú ǆemal", "Ñandú ǅemal"},
+		{"dedup", dedup, "aAbBBa", "ABa"},
+		{"empty", NewTitleCaseTransformer(), "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := TransformString(tt.transformer, tt.input)
+			if err != nil || result != tt.expected {
+				t.Errorf("TransformString(%q) = %q, %v; want %q", tt.input, result, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewDedupTransformerKeepLast(t *testing.T) {
+	if _, err := NewDedupTransformer(DedupOptions{KeepLast: true}); err == nil {
+		t.Error("NewDedupTransformer with KeepLast and not Adjacent succeeded; want an error")
+	}
+}
+
+func TestReaderMatchesStringFunctions(t *testing.T) {
+	// Long enough to cross buffer boundaries, with multi-byte runes split between reads
+	input := strings.Repeat("ñandú  AAbb éÉ 中文文 hELLO ", 2000)
+
+	tests := []struct {
+		name        string
+		transformer func() Transformer
+		expected    string
+	}{
+		{"title case", NewTitleCaseTransformer, ToTitleCase(input)},
+		{"dedup", func() Transformer {
+			tr, _ := NewDedupTransformer(DedupOptions{})
+			return tr
+		}, RemoveDuplicateChars(input)},
+		{"squeeze", func() Transformer {
+			tr, _ := NewDedupTransformer(DedupOptions{Adjacent: true, IgnoreCase: true, KeepLast: true})
+			return tr
+		}, RemoveDuplicates(input, DedupOptions{Adjacent: true, IgnoreCase: true, KeepLast: true})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, reader := range []io.Reader{
+				strings.NewReader(input),
+				iotest.OneByteReader(strings.NewReader(input)),
+			} {
+				result, err := io.ReadAll(NewReader(reader, tt.transformer()))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(result) != tt.expected {
+					t.Errorf("NewReader output differs from the string function: got %d bytes, want %d", len(result), len(tt.expected))
+				}
+			}
+		})
+	}
+}
+
+func TestReaderPropagatesErrors(t *testing.T) {
+	errBroken := errors.New("broken")
+	r := NewReader(io.MultiReader(strings.NewReader("abc"), iotest.ErrReader(errBroken)), NewTitleCaseTransformer())
+	result, err := io.ReadAll(r)
+	if !errors.Is(err, errBroken) || string(result) != "Abc" {
+		t.Errorf("ReadAll = %q, %v; want %q, %v", result, err, "Abc", errBroken)
+	}
+}
@@ -0,0 +1,75 @@
+package stringutils
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// lowerTransliterations maps lowercase letters without an ASCII decomposition to their
+// usual romanization; uppercase forms are derived from them
+var lowerTransliterations = map[rune]string{
+	// Latin ligatures and letters
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'þ': "th", 'ð': "d", 'ĳ': "ij",
+
+	// Cyrillic (Russian, Ukrainian and Belarusian)
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya", 'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g", 'ў': "u",
+
+	// Greek; accented vowels decompose to these
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th",
+	'ι': "i", 'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p",
+	'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t", 'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps",
+	'ω': "o",
+}
+
+// transliterations maps non-ASCII runes to ASCII, in both cases, plus typographic punctuation
+var transliterations = func() map[rune]string {
+	m := map[rune]string{
+		'ẞ': "SS",
+		'‘': "'", '’': "'", '‚': "'", '“': "\"", '”': "\"", '„': "\"", '«': "\"", '»': "\"",
+		'–': "-", '—': "-", '…': "...", ' ': " ",
+	}
+	for r, s := range lowerTransliterations {
+		m[r] = s
+		if upper := unicode.ToUpper(r); upper != r {
+			if _, ok := m[upper]; !ok {
+				m[upper] = capitalizeWord(s)
+			}
+		}
+	}
+	return m
+}()
+
+// Transliterate converts s to ASCII: accents are removed, and letters such as ß, Cyrillic
+// and Greek are romanized, e.g. "Straße" becomes "Strasse", "Москва" becomes "Moskva"
+// and "Αθήνα" becomes "Athina"; runes with no transliteration are dropped
+func Transliterate(s string) string {
+	if isASCII(s) {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	var buf [8]rune
+	for _, r := range NFC(s) {
+		if t, ok := transliterations[r]; ok {
+			b.WriteString(t)
+			continue
+		}
+		for _, d := range decompose(buf[:0], r) {
+			if f, ok := foldedLetters[d]; ok {
+				d = f
+			}
+			switch t, ok := transliterations[d]; {
+			case d < utf8.RuneSelf:
+				b.WriteRune(d)
+			case ok:
+				b.WriteString(t)
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,37 @@
+package stringutils
+
+import (
+	"testing"
+)
+
+func TestTransliterate(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"ascii", "Hello, World!", "Hello, World!"},
+		{"spanish", "¿Qué año? Ñandú", "Que ano? Nandu"},
+		{"german", "Straße Größe", "Strasse Grosse"},
+		{"capital sharp s", "STRAẞE", "STRASSE"},
+		{"ligatures", "Æsir œuvre", "Aesir oeuvre"},
+		{"stroke letters", "Łódź Øresund", "Lodz Oresund"},
+		{"russian", "Москва", "Moskva"},
+		{"russian soft sign", "Ярославль", "Yaroslavl"},
+		{"short i", "Андрей", "Andrey"},
+		{"ukrainian", "Львів", "Lviv"},
+		{"greek", "Αθήνα", "Athina"},
+		{"greek final sigma", "λόγος", "logos"},
+		{"decomposed input", "José", "Jose"},
+		{"punctuation", "“quoted” — it’s…", "\"quoted\" - it's..."},
+		{"unknown runes", "日本 ok", " ok"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := Transliterate(tt.input); result != tt.expected {
+				t.Errorf("Transliterate(%q) = %q; want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package stringutils
+
+import (
+	"testing"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected int
+	}{
+		{"ascii", "hello", 5},
+		{"empty", "", 0},
+		{"accents", "café", 4},
+		{"combining mark", "cafe\u0301", 4},
+		{"chinese", "中文", 4},
+		{"japanese mixed", "日本語abc", 9},
+		{"korean", "한국어", 6},
+		{"fullwidth", "ＡＢ", 4},
+		{"emoji", "🙂", 2},
+		{"emoji zwj sequence", "\U0001F469\u200D\U0001F4BB", 2},
+		{"flag", "🇪🇸", 2},
+		{"emoji presentation", "\u2764\uFE0F", 2},
+		{"text presentation", "❤", 1},
+		{"zero width space", "a\u200Bb", 2},
+		{"control", "a\tb", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := DisplayWidth(tt.input); result != tt.expected {
+				t.Errorf("DisplayWidth(%q) = %d; want %d", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
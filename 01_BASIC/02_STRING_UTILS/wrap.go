@@ -0,0 +1,260 @@
+package stringutils
+
+import (
+	"math"
+	"strings"
+)
+
+// WrapAlgorithm selects how a Wrapper chooses line breaks
+type WrapAlgorithm int
+
+const (
+	// WrapGreedy fills each line with as many words as fit before moving to the next
+	WrapGreedy WrapAlgorithm = iota
+	// WrapMinimumRaggedness chooses the breaks that minimize the sum of the squared space
+	// left at the end of every line but the last, as Knuth and Plass do for paragraphs,
+	// so lines come out more even than with greedy filling
+	WrapMinimumRaggedness
+)
+
+// Wrapper wraps text to a width measured in terminal columns with DisplayWidth
+// Paragraphs are separated by blank lines; other line breaks and runs of spaces are
+// treated as a single space
+type Wrapper struct {
+	// Width is the maximum display width of a line, including indentation; 0 means no limit
+	Width     int
+	Algorithm WrapAlgorithm
+	// Justify stretches the spaces between words so every line but the last of each
+	// paragraph is exactly Width columns wide
+	Justify bool
+	// Indent prefixes the first line of each paragraph
+	Indent string
+	// HangingIndent prefixes the other lines of each paragraph
+	HangingIndent string
+}
+
+// Wrap wraps s to width columns, breaking lines greedily between words
+func Wrap(s string, width int) string {
+	return Wrapper{Width: width}.Wrap(s)
+}
+
+// Wrap wraps s and joins the lines with newlines
+func (w Wrapper) Wrap(s string) string {
+	return strings.Join(w.Lines(s), "\n")
+}
+
+// Lines wraps s and returns its lines, with an empty line between paragraphs
+// Words wider than a line are broken between characters
+func (w Wrapper) Lines(s string) []string {
+	var lines []string
+	for i, paragraph := range paragraphs(s) {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, w.wrapParagraph(strings.Fields(paragraph))...)
+	}
+	return lines
+}
+
+// paragraphs splits s at blank lines, dropping empty paragraphs
+func paragraphs(s string) []string {
+	var result []string
+	var current []string
+	for _, line := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) == "" {
+			if len(current) > 0 {
+				result = append(result, strings.Join(current, " "))
+				current = nil
+			}
+			continue
+		}
+		current = append(current, line)
+	}
+	if len(current) > 0 {
+		result = append(result, strings.Join(current, " "))
+	}
+	return result
+}
+
+// available returns the width left for words on the first or a following line
+func (w Wrapper) available(first bool) int {
+	if w.Width <= 0 {
+		return math.MaxInt / 2
+	}
+	indent := w.HangingIndent
+	if first {
+		indent = w.Indent
+	}
+	return max(w.Width-DisplayWidth(indent), 1)
+}
+
+func (w Wrapper) wrapParagraph(words []string) []string {
+	if len(words) == 0 {
+		return nil
+	}
+	words = breakLongWords(words, min(w.available(true), w.available(false)))
+	widths := make([]int, len(words))
+	for i, word := range words {
+		widths[i] = DisplayWidth(word)
+	}
+
+	var breaks []int
+	if w.Algorithm == WrapMinimumRaggedness {
+		breaks = w.breaksMinimumRaggedness(widths)
+	} else {
+		breaks = w.breaksGreedy(widths)
+	}
+
+	lines := make([]string, 0, len(breaks))
+	start := 0
+	for i, end := range breaks {
+		indent := w.HangingIndent
+		if i == 0 {
+			indent = w.Indent
+		}
+		line := words[start:end]
+		if w.Justify && w.Width > 0 && end < len(words) {
+			lines = append(lines, indent+justify(line, widths[start:end], w.available(i == 0)))
+		} else {
+			lines = append(lines, indent+strings.Join(line, " "))
+		}
+		start = end
+	}
+	return lines
+}
+
+// breakLongWords splits words wider than width between grapheme clusters
+func breakLongWords(words []string, width int) []string {
+	var result []string
+	for _, word := range words {
+		if DisplayWidth(word) <= width {
+			result = append(result, word)
+			continue
+		}
+		var chunk strings.Builder
+		chunkWidth := 0
+		for _, cluster := range Graphemes(word) {
+			cw := clusterWidth(cluster)
+			if chunkWidth+cw > width && chunkWidth > 0 {
+				result = append(result, chunk.String())
+				chunk.Reset()
+				chunkWidth = 0
+			}
+			chunk.WriteString(cluster)
+			chunkWidth += cw
+		}
+		result = append(result, chunk.String())
+	}
+	return result
+}
+
+// breaksGreedy returns the index after the last word of each line
+func (w Wrapper) breaksGreedy(widths []int) []int {
+	var breaks []int
+	lineWidth := -1
+	for i, width := range widths {
+		if lineWidth >= 0 && lineWidth+1+width > w.available(len(breaks) == 0) {
+			breaks = append(breaks, i)
+			lineWidth = -1
+		}
+		lineWidth += 1 + width
+	}
+	return append(breaks, len(widths))
+}
+
+// breaksMinimumRaggedness returns the index after the last word of each line, found by
+// dynamic programming from the end of the paragraph: cost[i] is the least cost of laying
+// out the words from i on, where the last line is free
+func (w Wrapper) breaksMinimumRaggedness(widths []int) []int {
+	n := len(widths)
+	cost := make([]int, n+1)
+	next := make([]int, n+1)
+	for i := n - 1; i >= 0; i-- {
+		available := w.available(i == 0)
+		cost[i] = math.MaxInt
+		lineWidth := -1
+		for j := i; j < n; j++ {
+			lineWidth += 1 + widths[j]
+			if lineWidth > available && j > i {
+				break
+			}
+			c := 0
+			if j+1 < n {
+				slack := available - lineWidth
+				c = slack*slack + cost[j+1]
+			}
+			if c < cost[i] {
+				cost[i], next[i] = c, j+1
+			}
+		}
+	}
+
+	var breaks []int
+	for i := 0; i < n; i = next[i] {
+		breaks = append(breaks, next[i])
+	}
+	return breaks
+}
+
+// justify joins words with spaces stretched to fill width, giving the extra spaces
+// to the leftmost gaps
+func justify(words []string, widths []int, width int) string {
+	if len(words) == 1 {
+		return words[0]
+	}
+	gaps := len(words) - 1
+	extra := width - gaps
+	for _, w := range widths {
+		extra -= w
+	}
+	extra = max(extra, 0)
+
+	var b strings.Builder
+	for i, word := range words {
+		if i > 0 {
+			spaces := 1 + extra/gaps
+			if i <= extra%gaps {
+				spaces++
+			}
+			b.WriteString(strings.Repeat(" ", spaces))
+		}
+		b.WriteString(word)
+	}
+	return b.String()
+}
+
+// Column is a block of text laid out by Columns
+type Column struct {
+	Text string
+	// Wrapper wraps Text; its Width is the width of the column
+	Wrapper Wrapper
+}
+
+// Columns lays out columns side by side, separated by gap, and returns the lines
+// Every column is wrapped and padded to its width, and shorter columns are filled with
+// blank space; trailing spaces are trimmed from each line
+func Columns(gap string, columns ...Column) []string {
+	wrapped := make([][]string, len(columns))
+	height := 0
+	for i, c := range columns {
+		wrapped[i] = c.Wrapper.Lines(c.Text)
+		height = max(height, len(wrapped[i]))
+	}
+
+	lines := make([]string, height)
+	for row := range lines {
+		var b strings.Builder
+		for i, c := range columns {
+			if i > 0 {
+				b.WriteString(gap)
+			}
+			cell := ""
+			if row < len(wrapped[i]) {
+				cell = wrapped[i][row]
+			}
+			b.WriteString(padRight(cell, c.Wrapper.Width))
+		}
+		lines[row] = strings.TrimRight(b.String(), " ")
+	}
+	return lines
+}
@@ -0,0 +1,108 @@
+package stringutils
+
+import (
+	"slices"
+	"testing"
+)
+
+const wrapText = "aaa bb cc ddddd"
+
+func TestWrapper(t *testing.T) {
+	tests := []struct {
+		name     string
+		wrapper  Wrapper
+		input    string
+		expected []string
+	}{
+		{
+			name:     "greedy",
+			wrapper:  Wrapper{Width: 6},
+			input:    wrapText,
+			expected: []string{"aaa bb", "cc", "ddddd"},
+		},
+		{
+			name:     "minimum raggedness",
+			wrapper:  Wrapper{Width: 6, Algorithm: WrapMinimumRaggedness},
+			input:    wrapText,
+			expected: []string{"aaa", "bb cc", "ddddd"},
+		},
+		{
+			name:     "greedy versus minimum raggedness",
+			wrapper:  Wrapper{Width: 10, Algorithm: WrapMinimumRaggedness},
+			input:    "the quick brown fox jumps over",
+			expected: []string{"the quick", "brown fox", "jumps over"},
+		},
+		{
+			name:     "justify",
+			wrapper:  Wrapper{Width: 16, Justify: true},
+			input:    "the quick brown fox jumps over the lazy dog",
+			expected: []string{"the  quick brown", "fox  jumps  over", "the lazy dog"},
+		},
+		{
+			name:     "hanging indent",
+			wrapper:  Wrapper{Width: 12, Indent: "- ", HangingIndent: "  "},
+			input:    "first item is long enough to wrap",
+			expected: []string{"- first item", "  is long", "  enough to", "  wrap"},
+		},
+		{
+			name:     "paragraphs",
+			wrapper:  Wrapper{Width: 20},
+			input:    "first paragraph\nstill first\n\n\nsecond",
+			expected: []string{"first paragraph", "still first", "", "second"},
+		},
+		{
+			name:     "long word",
+			wrapper:  Wrapper{Width: 4},
+			input:    "abcdefghij ok",
+			expected: []string{"abcd", "efgh", "ij", "ok"},
+		},
+		{
+			name:     "wide characters",
+			wrapper:  Wrapper{Width: 5},
+			input:    "中文 字 日本",
+			expected: []string{"中文", "字", "日本"},
+		},
+		{
+			name:     "no limit",
+			wrapper:  Wrapper{},
+			input:    "  spaced   out  ",
+			expected: []string{"spaced out"},
+		},
+		{
+			name:     "empty",
+			wrapper:  Wrapper{Width: 10},
+			input:    " \n ",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.wrapper.Lines(tt.input); !slices.Equal(result, tt.expected) {
+				t.Errorf("Lines(%q) = %q; want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	if result, expected := Wrap(wrapText, 6), "aaa bb\ncc\nddddd"; result != expected {
+		t.Errorf("Wrap(%q, 6) = %q; want %q", wrapText, result, expected)
+	}
+}
+
+func TestColumns(t *testing.T) {
+	result := Columns(" | ",
+		Column{Text: "name age city", Wrapper: Wrapper{Width: 5}},
+		Column{Text: "中文 日本語", Wrapper: Wrapper{Width: 6}},
+		Column{Text: "x", Wrapper: Wrapper{Width: 1}},
+	)
+	expected := []string{
+		"name  | 中文   | x",
+		"age   | 日本語 |",
+		"city  |        |",
+	}
+	if !slices.Equal(result, expected) {
+		t.Errorf("Columns() = %q; want %q", result, expected)
+	}
+}
@@ -1,43 +1,140 @@
 package arrayops
 
 import (
+	"cmp"
 	"errors"
+	"math"
 )
 
+// ErrEmptySlice is returned by functions that are undefined for an empty slice
+var ErrEmptySlice = errors.New("empty slice")
+
+// Integer is the set of integer types
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Float is the set of floating-point types
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number is the set of types that support arithmetic
+type Number interface {
+	Integer | Float
+}
+
 // Sum calculates the sum of all elements in a slice
 func Sum(numbers []int) int {
-	// TODO: Implement this function
-	return 0
+	return SumOf(numbers)
 }
 
 // Average calculates the average of elements in a slice
-func Average(numbers []float64) float64 {
-	// TODO: Implement this function
-	return 0
+// Returns ErrEmptySlice if the slice is empty
+func Average(numbers []float64) (float64, error) {
+	return AverageOf(numbers)
 }
 
 // Max finds the maximum value in a slice
-// Returns an error if the slice is empty
+// Returns ErrEmptySlice if the slice is empty
 func Max(numbers []int) (int, error) {
-	// TODO: Implement this function
-	return 0, nil
+	return MaxOf(numbers)
 }
 
 // Filter filters the elements of a slice according to a predicate function
 func Filter(numbers []int, f func(int) bool) []int {
-	// TODO: Implement this function
-	return nil
+	return FilterOf(numbers, f)
 }
 
 // Map applies a function to each element of a slice
 func Map(numbers []int, f func(int) int) []int {
-	// TODO: Implement this function
-	return nil
+	return MapOf(numbers, f)
 }
 
 // Merge combines two slices by alternating their elements
 // If the slices have different lengths, it adds the remaining elements at the end
 func Merge(a, b []int) []int {
-	// TODO: Implement this function
-	return nil
-}
\ No newline at end of file
+	return MergeOf(a, b)
+}
+
+// SumOf calculates the sum of all elements in a slice of any numeric type
+func SumOf[T Number](numbers []T) T {
+	var total T
+	for _, n := range numbers {
+		total += n
+	}
+	return total
+}
+
+// AverageOf calculates the average of elements in a slice of any numeric type
+// The elements are added with CompensatedSum's algorithm, so large and small values do not
+// lose precision, and sums beyond the float64 range do not overflow
+// NaN and infinite elements give the IEEE result, as with CompensatedSum
+// Returns ErrEmptySlice if the slice is empty
+func AverageOf[T Number](numbers []T) (float64, error) {
+	if len(numbers) == 0 {
+		return 0, ErrEmptySlice
+	}
+	n := float64(len(numbers))
+	if sum := compensatedSum(numbers, 1); !math.IsInf(sum, 0) || hasInf(numbers) {
+		return sum / n, nil
+	}
+	// The sum overflowed but the average fits: add the elements already divided
+	return compensatedSum(numbers, 1/n), nil
+}
+
+func hasInf[T Number](numbers []T) bool {
+	for _, x := range numbers {
+		if math.IsInf(float64(x), 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxOf finds the maximum value in a slice of any ordered type
+// Returns ErrEmptySlice if the slice is empty
+func MaxOf[T cmp.Ordered](numbers []T) (T, error) {
+	if len(numbers) == 0 {
+		var zero T
+		return zero, ErrEmptySlice
+	}
+	m := numbers[0]
+	for _, n := range numbers[1:] {
+		m = max(m, n)
+	}
+	return m, nil
+}
+
+// FilterOf filters the elements of a slice of any type according to a predicate function
+// The result is never nil
+func FilterOf[T any](items []T, f func(T) bool) []T {
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		if f(item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// MapOf applies a function to each element of a slice, which may change the element type
+func MapOf[T, U any](items []T, f func(T) U) []U {
+	result := make([]U, len(items))
+	for i, item := range items {
+		result[i] = f(item)
+	}
+	return result
+}
+
+// MergeOf combines two slices of any type by alternating their elements
+// If the slices have different lengths, it adds the remaining elements at the end
+func MergeOf[T any](a, b []T) []T {
+	result := make([]T, 0, len(a)+len(b))
+	n := min(len(a), len(b))
+	for i := range n {
+		result = append(result, a[i], b[i])
+	}
+	result = append(result, a[n:]...)
+	return append(result, b[n:]...)
+}
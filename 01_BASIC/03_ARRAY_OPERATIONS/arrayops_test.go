@@ -35,7 +35,6 @@ func TestAverage(t *testing.T) {
 		input    []float64
 		expected float64
 	}{
-		{"empty slice", []float64{}, 0},
 		{"single element", []float64{5}, 5},
 		{"integers", []float64{1, 2, 3, 4, 5}, 3},
 		{"decimals", []float64{1.5, 2.5, 3.5}, 2.5},
@@ -45,14 +44,20 @@ func TestAverage(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := Average(tt.input)
-			if result != tt.expected {
-				t.Errorf("Average(%v) = %f; want %f", tt.input, result, tt.expected)
+			result, err := Average(tt.input)
+			if err != nil || result != tt.expected {
+				t.Errorf("Average(%v) = %f, %v; want %f", tt.input, result, err, tt.expected)
 			}
 		})
 	}
 }
 
+func TestAverageEmpty(t *testing.T) {
+	if _, err := Average([]float64{}); !errors.Is(err, ErrEmptySlice) {
+		t.Errorf("Average([]) error = %v; want ErrEmptySlice", err)
+	}
+}
+
 func TestMax(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -205,4 +210,62 @@ func TestMerge(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestGenericTypes(t *testing.T) {
+	type celsius float32
+
+	if result := SumOf([]int64{1 << 40, 1 << 40}); result != 1<<41 {
+		t.Errorf("SumOf([]int64) = %d; want %d", result, int64(1<<41))
+	}
+	if result := SumOf([]celsius{20.5, 1.5}); result != 22 {
+		t.Errorf("SumOf([]celsius) = %v; want 22", result)
+	}
+	if result, err := AverageOf([]uint8{250, 252}); err != nil || result != 251 {
+		t.Errorf("AverageOf([]uint8) = %v, %v; want 251", result, err)
+	}
+	if result, err := MaxOf([]string{"pear", "apple", "zucchini"}); err != nil || result != "zucchini" {
+		t.Errorf("MaxOf([]string) = %q, %v; want %q", result, err, "zucchini")
+	}
+	if _, err := MaxOf([]float64{}); !errors.Is(err, ErrEmptySlice) {
+		t.Errorf("MaxOf([]float64{}) error = %v; want ErrEmptySlice", err)
+	}
+
+	type user struct {
+		name string
+		age  int
+	}
+	users := []user{{"ana", 31}, {"luis", 17}, {"eva", 45}}
+	adults := FilterOf(users, func(u user) bool { return u.age >= 18 })
+	names := MapOf(adults, func(u user) string { return u.name })
+	if !reflect.DeepEqual(names, []string{"ana", "eva"}) {
+		t.Errorf("MapOf(FilterOf(users)) = %v; want [ana eva]", names)
+	}
+	if result := MergeOf([]string{"a", "c"}, []string{"b"}); !reflect.DeepEqual(result, []string{"a", "b", "c"}) {
+		t.Errorf("MergeOf([]string) = %v; want [a b c]", result)
+	}
+}
+
+// The int-based functions keep their signatures alongside the generic ones
+var (
+	_ func([]int) int                   = Sum
+	_ func([]float64) (float64, error)  = Average
+	_ func([]int) (int, error)          = Max
+	_ func([]int, func(int) bool) []int = Filter
+	_ func([]int, func(int) int) []int  = Map
+	_ func(a, b []int) []int            = Merge
+)
+
+func TestIntWrappers(t *testing.T) {
+	// The int-based functions are still plain functions, so they can be used as values
+	sum, maximum := Sum, Max
+	if result := sum([]int{1, 2, 3}); result != 6 {
+		t.Errorf("sum([1 2 3]) = %d; want 6", result)
+	}
+	if result, err := maximum([]int{4, 9, 2}); err != nil || result != 9 {
+		t.Errorf("maximum([4 9 2]) = %d, %v; want 9", result, err)
+	}
+	if _, err := maximum([]int{}); !errors.Is(err, ErrEmptySlice) {
+		t.Errorf("maximum([]) error = %v; want ErrEmptySlice", err)
+	}
+}
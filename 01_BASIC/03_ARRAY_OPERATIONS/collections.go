@@ -0,0 +1,96 @@
+package arrayops
+
+// Reduce folds a slice into a single value, calling f with the accumulated value and
+// each element in order, starting from initial
+func Reduce[T, A any](items []T, initial A, f func(A, T) A) A {
+	acc := initial
+	for _, item := range items {
+		acc = f(acc, item)
+	}
+	return acc
+}
+
+// GroupBy groups the elements of a slice by the key f returns for them, keeping their order
+func GroupBy[T any, K comparable](items []T, f func(T) K) map[K][]T {
+	groups := make(map[K][]T)
+	for _, item := range items {
+		k := f(item)
+		groups[k] = append(groups[k], item)
+	}
+	return groups
+}
+
+// Partition splits a slice into the elements that satisfy a predicate and those that do not
+// Neither result is nil
+func Partition[T any](items []T, f func(T) bool) (matched, rest []T) {
+	matched, rest = []T{}, []T{}
+	for _, item := range items {
+		if f(item) {
+			matched = append(matched, item)
+		} else {
+			rest = append(rest, item)
+		}
+	}
+	return matched, rest
+}
+
+// Chunk splits a slice into consecutive chunks of size elements; the last one may be shorter
+// The chunks share the memory of items but cannot append into each other
+// Panics if size is not positive
+func Chunk[T any](items []T, size int) [][]T {
+	if size <= 0 {
+		panic("arrayops: chunk size must be positive")
+	}
+	// Count the chunks without adding size first, which overflows for sizes near math.MaxInt
+	count := len(items) / size
+	if len(items)%size != 0 {
+		count++
+	}
+	chunks := make([][]T, 0, count)
+	for i := 0; i < len(items); i += size {
+		end := i + min(size, len(items)-i)
+		chunks = append(chunks, items[i:end:end])
+	}
+	return chunks
+}
+
+// Pair holds two values of any types
+type Pair[T, U any] struct {
+	First  T
+	Second U
+}
+
+// Zip pairs the elements of two slices by position, stopping at the end of the shorter one
+func Zip[T, U any](a []T, b []U) []Pair[T, U] {
+	pairs := make([]Pair[T, U], min(len(a), len(b)))
+	for i := range pairs {
+		pairs[i] = Pair[T, U]{a[i], b[i]}
+	}
+	return pairs
+}
+
+// Flatten concatenates a slice of slices into a single slice
+func Flatten[T any](slices [][]T) []T {
+	n := 0
+	for _, s := range slices {
+		n += len(s)
+	}
+	result := make([]T, 0, n)
+	for _, s := range slices {
+		result = append(result, s...)
+	}
+	return result
+}
+
+// Uniq removes duplicate elements from a slice, keeping the first occurrence of each
+func Uniq[T comparable](items []T) []T {
+	seen := make(map[T]bool, len(items))
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		if !seen[item] {
+			seen[item] = true
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -0,0 +1,148 @@
+package arrayops
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestReduce(t *testing.T) {
+	product := Reduce([]int{1, 2, 3, 4}, 1, func(acc, n int) int { return acc * n })
+	if product != 24 {
+		t.Errorf("Reduce(product) = %d; want 24", product)
+	}
+	joined := Reduce([]int{1, 2, 3}, "", func(acc string, n int) string { return acc + strconv.Itoa(n) })
+	if joined != "123" {
+		t.Errorf("Reduce(join) = %q; want %q", joined, "123")
+	}
+	if result := Reduce([]int{}, 7, func(acc, n int) int { return acc + n }); result != 7 {
+		t.Errorf("Reduce(empty) = %d; want 7", result)
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	words := []string{"apple", "avocado", "banana", "blueberry", "cherry"}
+	result := GroupBy(words, func(w string) byte { return w[0] })
+	expected := map[byte][]string{
+		'a': {"apple", "avocado"},
+		'b': {"banana", "blueberry"},
+		'c': {"cherry"},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("GroupBy(%v) = %v; want %v", words, result, expected)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []int
+		matched []int
+		rest    []int
+	}{
+		{"mixed", []int{1, 2, 3, 4, 5}, []int{2, 4}, []int{1, 3, 5}},
+		{"none match", []int{1, 3}, []int{}, []int{1, 3}},
+		{"empty", []int{}, []int{}, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched, rest := Partition(tt.input, func(n int) bool { return n%2 == 0 })
+			if !reflect.DeepEqual(matched, tt.matched) || !reflect.DeepEqual(rest, tt.rest) {
+				t.Errorf("Partition(%v) = %v, %v; want %v, %v", tt.input, matched, rest, tt.matched, tt.rest)
+			}
+		})
+	}
+}
+
+func TestChunk(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []int
+		size     int
+		expected [][]int
+	}{
+		{"even", []int{1, 2, 3, 4}, 2, [][]int{{1, 2}, {3, 4}}},
+		{"remainder", []int{1, 2, 3, 4, 5}, 2, [][]int{{1, 2}, {3, 4}, {5}}},
+		{"larger than slice", []int{1, 2}, 5, [][]int{{1, 2}}},
+		{"empty", []int{}, 3, [][]int{}},
+		{"max int size", []int{1, 2, 3}, math.MaxInt, [][]int{{1, 2, 3}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := Chunk(tt.input, tt.size); !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Chunk(%v, %d) = %v; want %v", tt.input, tt.size, result, tt.expected)
+			}
+		})
+	}
+
+	t.Run("append does not overwrite", func(t *testing.T) {
+		input := []int{1, 2, 3, 4}
+		chunks := Chunk(input, 2)
+		_ = append(chunks[0], 99)
+		if input[2] != 3 {
+			t.Errorf("appending to a chunk changed the next one: %v", input)
+		}
+	})
+
+	t.Run("invalid size", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Chunk with size 0 did not panic")
+			}
+		}()
+		Chunk([]int{1}, 0)
+	})
+}
+
+func TestZip(t *testing.T) {
+	result := Zip([]string{"a", "b", "c"}, []int{1, 2})
+	expected := []Pair[string, int]{{"a", 1}, {"b", 2}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Zip = %v; want %v", result, expected)
+	}
+	if result := Zip([]int{}, []int{1}); len(result) != 0 {
+		t.Errorf("Zip(empty) = %v; want []", result)
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    [][]int
+		expected []int
+	}{
+		{"nested", [][]int{{1, 2}, {}, {3}, {4, 5}}, []int{1, 2, 3, 4, 5}},
+		{"empty", [][]int{}, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := Flatten(tt.input); !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Flatten(%v) = %v; want %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUniq(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []string
+		expected []string
+	}{
+		{"duplicates", []string{"b", "a", "b", "c", "a"}, []string{"b", "a", "c"}},
+		{"no duplicates", []string{"x", "y"}, []string{"x", "y"}},
+		{"empty", []string{}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := Uniq(tt.input); !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Uniq(%v) = %v; want %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
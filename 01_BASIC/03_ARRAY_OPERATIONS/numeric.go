@@ -0,0 +1,131 @@
+package arrayops
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+)
+
+// ErrOverflow is returned by CheckedSum when the sum does not fit in the element type
+var ErrOverflow = errors.New("integer overflow")
+
+// CheckedSum calculates the sum of all elements in a slice of integers
+// Unlike SumOf, which wraps around, it returns ErrOverflow if the sum does not fit in T
+func CheckedSum[T Integer](numbers []T) (T, error) {
+	var total T
+	for _, n := range numbers {
+		s := total + n
+		if (n > 0 && s < total) || (n < 0 && s > total) {
+			return 0, ErrOverflow
+		}
+		total = s
+	}
+	return total, nil
+}
+
+// CompensatedSum calculates the sum of a slice of floats with Neumaier's variant of Kahan
+// summation, which carries the rounding error of every addition, so adding 1e100, 1 and
+// -1e100 gives 1 rather than 0
+// NaN and infinite inputs give the IEEE result: NaN if any element is NaN or infinities of
+// both signs are present, and the infinity otherwise
+// A finite total is returned even when a partial sum overflows, e.g. 1e308, 1e308, -1e308 gives 1e308
+func CompensatedSum[T Float](numbers []T) T {
+	sum := compensatedSum(numbers, 1)
+	if math.IsInf(sum, 0) && !hasInf(numbers) {
+		// Retry with the elements scaled down by a power of two, which is exact and keeps every
+		// partial sum in range; scaling back only overflows if the total itself does
+		scale := math.Ldexp(1, -bits.Len(uint(len(numbers))))
+		sum = compensatedSum(numbers, scale) / scale
+	}
+	return T(sum)
+}
+
+// compensatedSum returns the compensated sum of numbers each multiplied by scale
+func compensatedSum[T Number](numbers []T, scale float64) float64 {
+	var sum, c, special float64
+	for _, n := range numbers {
+		x := float64(n) * scale
+		if math.IsInf(x, 0) || math.IsNaN(x) {
+			// Kept apart so they do not turn the compensation into NaN
+			special += x
+			continue
+		}
+		t := sum + x
+		if math.Abs(sum) >= math.Abs(x) {
+			c += (sum - t) + x
+		} else {
+			c += (x - t) + sum
+		}
+		sum = t
+	}
+	if special != 0 || math.IsNaN(special) {
+		return special
+	}
+	if math.IsInf(sum, 0) {
+		// The finite inputs overflowed; the compensation term is meaningless
+		return sum
+	}
+	return sum + c
+}
+
+// RunningStats accumulates the count, mean and variance of a stream of values with
+// Welford's algorithm, which avoids the cancellation of the sum-of-squares formula
+// NaN values make the mean and variance NaN
+// The zero value is ready to use
+type RunningStats struct {
+	n        int
+	mean, m2 float64
+}
+
+// Push adds x to the statistics
+func (s *RunningStats) Push(x float64) {
+	s.n++
+	delta := x - s.mean
+	s.mean += delta / float64(s.n)
+	s.m2 += delta * (x - s.mean)
+}
+
+// Count returns the number of values pushed
+func (s *RunningStats) Count() int {
+	return s.n
+}
+
+// Mean returns the mean of the values pushed
+// Returns ErrEmptySlice if there are none
+func (s *RunningStats) Mean() (float64, error) {
+	if s.n == 0 {
+		return 0, ErrEmptySlice
+	}
+	return s.mean, nil
+}
+
+// Variance returns the sample variance of the values pushed, dividing by n - 1
+// Returns ErrEmptySlice if there are none; a single value has no sample variance
+func (s *RunningStats) Variance() (float64, error) {
+	if s.n == 0 {
+		return 0, ErrEmptySlice
+	}
+	if s.n == 1 {
+		return 0, errors.New("sample variance needs at least 2 values")
+	}
+	return s.m2 / float64(s.n-1), nil
+}
+
+// PopulationVariance returns the population variance of the values pushed, dividing by n
+// Returns ErrEmptySlice if there are none
+func (s *RunningStats) PopulationVariance() (float64, error) {
+	if s.n == 0 {
+		return 0, ErrEmptySlice
+	}
+	return s.m2 / float64(s.n), nil
+}
+
+// Variance calculates the sample variance of a slice in one pass with RunningStats
+// Returns ErrEmptySlice if the slice is empty; a single value has no sample variance
+func Variance[T Number](numbers []T) (float64, error) {
+	var s RunningStats
+	for _, n := range numbers {
+		s.Push(float64(n))
+	}
+	return s.Variance()
+}
@@ -0,0 +1,134 @@
+package arrayops
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestCheckedSum(t *testing.T) {
+	if result, err := CheckedSum([]int{1, 2, 3}); err != nil || result != 6 {
+		t.Errorf("CheckedSum([1 2 3]) = %d, %v; want 6", result, err)
+	}
+	if result, err := CheckedSum([]int8{100, 27, -50}); err != nil || result != 77 {
+		t.Errorf("CheckedSum([100 27 -50]) = %d, %v; want 77", result, err)
+	}
+
+	overflows := []struct {
+		name string
+		sum  func() error
+	}{
+		{"int8 positive", func() error { _, err := CheckedSum([]int8{100, 28}); return err }},
+		{"int8 negative", func() error { _, err := CheckedSum([]int8{-100, -29}); return err }},
+		{"int64", func() error { _, err := CheckedSum([]int64{math.MaxInt64, 1}); return err }},
+		{"uint8", func() error { _, err := CheckedSum([]uint8{200, 56}); return err }},
+		{"uint64", func() error { _, err := CheckedSum([]uint64{math.MaxUint64, 1}); return err }},
+	}
+	for _, tt := range overflows {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.sum(); !errors.Is(err, ErrOverflow) {
+				t.Errorf("CheckedSum error = %v; want ErrOverflow", err)
+			}
+		})
+	}
+}
+
+func TestCompensatedSum(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []float64
+		expected float64
+	}{
+		{"cancellation", []float64{1e100, 1, -1e100}, 1},
+		{"small values", []float64{1, 1e-16, 1e-16, 1e-16, 1e-16}, 1 + 4e-16},
+		{"empty", []float64{}, 0},
+		{"infinity", []float64{1, math.Inf(1), 2}, math.Inf(1)},
+		{"partial sum overflow", []float64{1e308, 1e308, -1e308}, 1e308},
+		{"overflow", []float64{math.MaxFloat64, math.MaxFloat64}, math.Inf(1)},
+		{"negative overflow", []float64{-math.MaxFloat64, -math.MaxFloat64, 1}, math.Inf(-1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := CompensatedSum(tt.input); result != tt.expected {
+				t.Errorf("CompensatedSum(%v) = %g; want %g", tt.input, result, tt.expected)
+			}
+		})
+	}
+
+	for _, input := range [][]float64{{1, math.NaN()}, {math.Inf(1), math.Inf(-1)}} {
+		if result := CompensatedSum(input); !math.IsNaN(result) {
+			t.Errorf("CompensatedSum(%v) = %g; want NaN", input, result)
+		}
+	}
+
+	// Adding 0.1 a million times drifts with plain summation but not with compensation
+	tenths := make([]float64, 1_000_000)
+	for i := range tenths {
+		tenths[i] = 0.1
+	}
+	if result := CompensatedSum(tenths); result != 1e5 {
+		t.Errorf("CompensatedSum(0.1 x 1e6) = %.10f; want 100000", result)
+	}
+	if float32Sum := CompensatedSum([]float32{1e8, 1, 1, -1e8}); float32Sum != 2 {
+		t.Errorf("CompensatedSum(float32) = %g; want 2", float32Sum)
+	}
+}
+
+func TestAverageStability(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []float64
+		expected float64
+	}{
+		{"large and small", []float64{1e100, 3, -1e100}, 1},
+		{"sum beyond range", []float64{math.MaxFloat64, math.MaxFloat64}, math.MaxFloat64},
+		{"infinity", []float64{1, math.Inf(-1)}, math.Inf(-1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result, err := Average(tt.input); err != nil || result != tt.expected {
+				t.Errorf("Average(%v) = %g, %v; want %g", tt.input, result, err, tt.expected)
+			}
+		})
+	}
+
+	if result, _ := Average([]float64{1, math.NaN()}); !math.IsNaN(result) {
+		t.Errorf("Average with NaN = %g; want NaN", result)
+	}
+}
+
+func TestRunningStats(t *testing.T) {
+	var s RunningStats
+	if _, err := s.Mean(); !errors.Is(err, ErrEmptySlice) {
+		t.Errorf("Mean of no values error = %v; want ErrEmptySlice", err)
+	}
+
+	// A large offset makes the naive sum-of-squares formula lose every digit
+	for _, x := range []float64{4, 7, 13, 16} {
+		s.Push(1e9 + x)
+	}
+	mean, _ := s.Mean()
+	variance, _ := s.Variance()
+	population, _ := s.PopulationVariance()
+	if s.Count() != 4 || mean != 1e9+10 || variance != 30 || population != 22.5 {
+		t.Errorf("RunningStats = count %d, mean %g, variance %g, population %g; want 4, %g, 30, 22.5",
+			s.Count(), mean, variance, population, 1e9+10)
+	}
+
+	var single RunningStats
+	single.Push(1)
+	if _, err := single.Variance(); err == nil {
+		t.Error("Variance of a single value succeeded; want an error")
+	}
+}
+
+func TestVariance(t *testing.T) {
+	if result, err := Variance([]int{2, 4, 4, 4, 5, 5, 7, 9}); err != nil || math.Abs(result-32.0/7) > 1e-12 {
+		t.Errorf("Variance = %g, %v; want %g", result, err, 32.0/7)
+	}
+	if _, err := Variance([]float64{}); !errors.Is(err, ErrEmptySlice) {
+		t.Errorf("Variance([]) error = %v; want ErrEmptySlice", err)
+	}
+}
@@ -0,0 +1,131 @@
+package arrayops
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+)
+
+// PanicError is returned by the parallel functions when the function they run panics
+type PanicError struct {
+	Value any    // the value passed to panic
+	Stack []byte // the stack of the goroutine that panicked
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("arrayops: worker panicked: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// chunksPerWorker splits the work finer than one chunk per goroutine, so a goroutine
+// that finishes early can take over chunks from slower ones
+const chunksPerWorker = 4
+
+// chunking returns the size and number of chunks that split n elements among GOMAXPROCS goroutines
+func chunking(n int) (size, count int) {
+	if n == 0 {
+		return 0, 0
+	}
+	parts := runtime.GOMAXPROCS(0) * chunksPerWorker
+	size = max(1, (n+parts-1)/parts)
+	return size, (n + size - 1) / size
+}
+
+// parallelChunks calls work for every chunk of n elements from a pool of GOMAXPROCS goroutines
+// Cancellation is checked between chunks; the first panic stops the remaining chunks
+// and is returned as a *PanicError
+func parallelChunks(ctx context.Context, n, size, count int, work func(chunk, lo, hi int)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for range min(runtime.GOMAXPROCS(0), count) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if v := recover(); v != nil {
+					cancel(&PanicError{Value: v, Stack: debug.Stack()})
+				}
+			}()
+			for ctx.Err() == nil {
+				c := int(next.Add(1) - 1)
+				if c >= count {
+					return
+				}
+				lo := c * size
+				work(c, lo, min(lo+size, n))
+			}
+		}()
+	}
+	wg.Wait()
+	return context.Cause(ctx)
+}
+
+// ParallelMap applies a function to each element of a slice, splitting the work among
+// GOMAXPROCS goroutines, and returns the results in the order of the input
+// If ctx is canceled it returns ctx's error, and if f panics it returns a *PanicError
+func ParallelMap[T, U any](ctx context.Context, items []T, f func(T) U) ([]U, error) {
+	result := make([]U, len(items))
+	size, count := chunking(len(items))
+	err := parallelChunks(ctx, len(items), size, count, func(_, lo, hi int) {
+		for i := lo; i < hi; i++ {
+			result[i] = f(items[i])
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ParallelFilter keeps the elements of a slice that satisfy a predicate, evaluating it
+// on GOMAXPROCS goroutines, and returns them in the order of the input
+// If ctx is canceled it returns ctx's error, and if f panics it returns a *PanicError
+func ParallelFilter[T any](ctx context.Context, items []T, f func(T) bool) ([]T, error) {
+	size, count := chunking(len(items))
+	parts := make([][]T, count)
+	err := parallelChunks(ctx, len(items), size, count, func(c, lo, hi int) {
+		parts[c] = FilterOf(items[lo:hi], f)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return Flatten(parts), nil
+}
+
+// ParallelReduce folds a slice into a single value on GOMAXPROCS goroutines: each chunk is
+// folded with f starting from identity, and the chunk results are merged in order with combine
+// f and combine must be associative and identity neutral for the result to match Reduce
+// If ctx is canceled it returns ctx's error, and if a function panics it returns a *PanicError
+func ParallelReduce[T, A any](ctx context.Context, items []T, identity A, f func(A, T) A, combine func(A, A) A) (A, error) {
+	size, count := chunking(len(items))
+	partials := make([]A, count)
+	err := parallelChunks(ctx, len(items), size, count, func(c, lo, hi int) {
+		partials[c] = Reduce(items[lo:hi], identity, f)
+	})
+	if err != nil {
+		var zero A
+		return zero, err
+	}
+	return Reduce(partials, identity, combine), nil
+}
+
+// ParallelSum calculates the sum of all elements in a slice on GOMAXPROCS goroutines
+// Floating-point sums may differ from SumOf in the last bits, as they are added in another order
+func ParallelSum[T Number](ctx context.Context, numbers []T) (T, error) {
+	add := func(a, b T) T { return a + b }
+	return ParallelReduce(ctx, numbers, 0, add, add)
+}
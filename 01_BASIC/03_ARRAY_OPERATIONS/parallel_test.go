@@ -0,0 +1,149 @@
+package arrayops
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+func sequence(n int) []int {
+	items := make([]int, n)
+	for i := range items {
+		items[i] = i
+	}
+	return items
+}
+
+// busy is a CPU-heavy function for the benchmarks
+func busy(n int) float64 {
+	x := float64(n)
+	for range 200 {
+		x = math.Sqrt(x*x + 1)
+	}
+	return x
+}
+
+func TestParallelMatchesSequential(t *testing.T) {
+	for _, n := range []int{0, 1, 7, 1000, 100003} {
+		t.Run(strconv.Itoa(n), func(t *testing.T) {
+			items := sequence(n)
+			ctx := context.Background()
+			square := func(v int) int { return v * v }
+			odd := func(v int) bool { return v%2 == 1 }
+
+			mapped, err := ParallelMap(ctx, items, square)
+			if err != nil || !reflect.DeepEqual(mapped, Map(items, square)) {
+				t.Errorf("ParallelMap differs from Map (err %v)", err)
+			}
+			filtered, err := ParallelFilter(ctx, items, odd)
+			if err != nil || !reflect.DeepEqual(filtered, Filter(items, odd)) {
+				t.Errorf("ParallelFilter differs from Filter (err %v)", err)
+			}
+			sum, err := ParallelSum(ctx, items)
+			if err != nil || sum != Sum(items) {
+				t.Errorf("ParallelSum = %d, %v; want %d", sum, err, Sum(items))
+			}
+		})
+	}
+}
+
+func TestParallelReduceKeepsOrder(t *testing.T) {
+	words := MapOf(sequence(500), strconv.Itoa)
+	concat := func(a, b string) string { return a + b }
+	result, err := ParallelReduce(context.Background(), words, "", concat, concat)
+	if expected := Reduce(words, "", concat); err != nil || result != expected {
+		t.Errorf("ParallelReduce = %.20q..., %v; want %.20q...", result, err, expected)
+	}
+}
+
+func TestParallelPanic(t *testing.T) {
+	errBoom := errors.New("boom")
+	_, err := ParallelMap(context.Background(), sequence(1000), func(v int) int {
+		if v == 500 {
+			panic(errBoom)
+		}
+		return v
+	})
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) || !errors.Is(err, errBoom) || len(panicErr.Stack) == 0 {
+		t.Errorf("ParallelMap error = %v; want a *PanicError wrapping %v", err, errBoom)
+	}
+
+	_, err = ParallelFilter(context.Background(), sequence(10), func(v int) bool { panic("bad predicate") })
+	if !errors.As(err, &panicErr) || panicErr.Value != "bad predicate" {
+		t.Errorf("ParallelFilter error = %v; want a *PanicError", err)
+	}
+}
+
+func TestParallelCancellation(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ParallelMap(canceled, sequence(10), func(v int) int { return v }); !errors.Is(err, context.Canceled) {
+		t.Errorf("ParallelMap with a canceled context error = %v; want context.Canceled", err)
+	}
+	if _, err := ParallelSum(canceled, []int{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("ParallelSum with a canceled context error = %v; want context.Canceled", err)
+	}
+
+	// Canceling midway stops the remaining chunks
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var calls atomic.Int64
+	_, err := ParallelFilter(ctx, sequence(100000), func(v int) bool {
+		if calls.Add(1) == 10 {
+			cancel()
+		}
+		return true
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ParallelFilter error = %v; want context.Canceled", err)
+	}
+	if calls.Load() == 100000 {
+		t.Error("ParallelFilter kept running after cancellation")
+	}
+}
+
+var benchItems = sequence(100000)
+
+func BenchmarkMap(b *testing.B) {
+	for b.Loop() {
+		MapOf(benchItems, busy)
+	}
+}
+
+func BenchmarkParallelMap(b *testing.B) {
+	for b.Loop() {
+		ParallelMap(context.Background(), benchItems, busy)
+	}
+}
+
+func BenchmarkFilter(b *testing.B) {
+	for b.Loop() {
+		Filter(benchItems, func(v int) bool { return busy(v) > 1000 })
+	}
+}
+
+func BenchmarkParallelFilter(b *testing.B) {
+	for b.Loop() {
+		ParallelFilter(context.Background(), benchItems, func(v int) bool { return busy(v) > 1000 })
+	}
+}
+
+func BenchmarkReduce(b *testing.B) {
+	add := func(acc float64, v int) float64 { return acc + busy(v) }
+	for b.Loop() {
+		Reduce(benchItems, 0, add)
+	}
+}
+
+func BenchmarkParallelReduce(b *testing.B) {
+	add := func(acc float64, v int) float64 { return acc + busy(v) }
+	combine := func(a, b float64) float64 { return a + b }
+	for b.Loop() {
+		ParallelReduce(context.Background(), benchItems, 0, add, combine)
+	}
+}
@@ -0,0 +1,123 @@
+package arrayops
+
+import (
+	"iter"
+	"math"
+)
+
+// FilterSeq lazily yields the elements of seq that satisfy a predicate
+func FilterSeq[T any](seq iter.Seq[T], f func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if f(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// MapSeq lazily yields the result of applying a function to each element of seq
+func MapSeq[T, U any](seq iter.Seq[T], f func(T) U) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for v := range seq {
+			if !yield(f(v)) {
+				return
+			}
+		}
+	}
+}
+
+// TakeWhile yields the elements of seq until the first one that does not satisfy a predicate,
+// so it can end an unbounded sequence
+func TakeWhile[T any](seq iter.Seq[T], f func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if !f(v) || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Take yields the first n elements of seq
+func Take[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		i := 0
+		for v := range seq {
+			if !yield(v) {
+				return
+			}
+			i++
+			if i == n {
+				return
+			}
+		}
+	}
+}
+
+// Skip yields the elements of seq after the first n
+func Skip[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		i := 0
+		for v := range seq {
+			if i < n {
+				i++
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Window yields every run of size consecutive elements of seq, sliding by one:
+// the windows of 2 over 1, 2, 3 are [1 2] and [2 3]
+// To avoid allocating per window, the slice yielded is only valid until the next one;
+// clone it to keep it. Panics if size is not positive
+func Window[T any](seq iter.Seq[T], size int) iter.Seq[[]T] {
+	if size <= 0 {
+		panic("arrayops: window size must be positive")
+	}
+	return func(yield func([]T) bool) {
+		// The window is the tail of buf; when buf reaches limit its last size-1 elements move to the front
+		// The slack past size is capped so huge sizes neither overflow nor allocate up front
+		limit := size + min(size, 1024, math.MaxInt-size)
+		buf := make([]T, 0, min(limit, 1024))
+		for v := range seq {
+			if len(buf) == limit {
+				buf = append(buf[:0], buf[len(buf)-size+1:]...)
+			}
+			buf = append(buf, v)
+			// Cap the window at its length so appending to it cannot write into buf
+			if len(buf) >= size && !yield(buf[len(buf)-size:len(buf):len(buf)]) {
+				return
+			}
+		}
+	}
+}
+
+// Enumerate yields the elements of seq with their position, starting at 0
+func Enumerate[T any](seq iter.Seq[T]) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for v := range seq {
+			if !yield(i, v) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Collect gathers the elements of a finite seq into a slice, which is never nil
+func Collect[T any](seq iter.Seq[T]) []T {
+	result := []T{}
+	for v := range seq {
+		result = append(result, v)
+	}
+	return result
+}
@@ -0,0 +1,146 @@
+package arrayops
+
+import (
+	"iter"
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// naturals yields 0, 1, 2, ... without end
+func naturals() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := 0; yield(i); i++ {
+		}
+	}
+}
+
+func isEven(n int) bool { return n%2 == 0 }
+
+func TestSeqPipeline(t *testing.T) {
+	tests := []struct {
+		name     string
+		seq      iter.Seq[int]
+		expected []int
+	}{
+		{"filter", FilterSeq(slices.Values([]int{1, 2, 3, 4, 5, 6}), isEven), []int{2, 4, 6}},
+		{"map", MapSeq(slices.Values([]int{1, 2, 3}), func(n int) int { return n * n }), []int{1, 4, 9}},
+		{"take while", TakeWhile(slices.Values([]int{1, 2, 5, 1}), func(n int) bool { return n < 3 }), []int{1, 2}},
+		{"take", Take(slices.Values([]int{1, 2, 3}), 2), []int{1, 2}},
+		{"take zero", Take(slices.Values([]int{1, 2, 3}), 0), []int{}},
+		{"skip", Skip(slices.Values([]int{1, 2, 3, 4}), 2), []int{3, 4}},
+		{"skip all", Skip(slices.Values([]int{1, 2}), 5), []int{}},
+		{"unbounded source", Take(Skip(FilterSeq(naturals(), isEven), 2), 3), []int{4, 6, 8}},
+		{"take while unbounded", TakeWhile(MapSeq(naturals(), func(n int) int { return n * n }), func(n int) bool { return n < 30 }), []int{0, 1, 4, 9, 16, 25}},
+		{"empty", FilterSeq(slices.Values([]int{}), isEven), []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := Collect(tt.seq); !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Collect = %v; want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWindow(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []int
+		size     int
+		expected [][]int
+	}{
+		{"pairs", []int{1, 2, 3, 4}, 2, [][]int{{1, 2}, {2, 3}, {3, 4}}},
+		{"triples", []int{1, 2, 3, 4, 5, 6, 7}, 3, [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}, {4, 5, 6}, {5, 6, 7}}},
+		{"single", []int{1, 2, 3}, 1, [][]int{{1}, {2}, {3}}},
+		{"too short", []int{1, 2}, 3, [][]int{}},
+		{"huge size", []int{1, 2}, math.MaxInt/2 + 1, [][]int{}},
+		{"max int size", []int{1, 2}, math.MaxInt, [][]int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Collect(MapSeq(Window(slices.Values(tt.input), tt.size), slices.Clone[[]int]))
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Window(%v, %d) = %v; want %v", tt.input, tt.size, result, tt.expected)
+			}
+		})
+	}
+
+	t.Run("append does not overwrite", func(t *testing.T) {
+		var extended []int
+		for w := range Window(slices.Values([]int{1, 2, 3, 4}), 2) {
+			if extended == nil {
+				extended = append(w, 99)
+			}
+		}
+		if !reflect.DeepEqual(extended, []int{1, 2, 99}) {
+			t.Errorf("appending to a window = %v after the next windows; want [1 2 99]", extended)
+		}
+	})
+
+	t.Run("moving average of unbounded source", func(t *testing.T) {
+		mean := func(w []int) float64 { return float64(Sum(w)) / float64(len(w)) }
+		averages := Take(MapSeq(Window(naturals(), 3), mean), 3)
+		if result := Collect(averages); !reflect.DeepEqual(result, []float64{1, 2, 3}) {
+			t.Errorf("moving averages = %v; want [1 2 3]", result)
+		}
+	})
+}
+
+func TestEnumerate(t *testing.T) {
+	var indices []int
+	var values []string
+	for i, v := range Enumerate(slices.Values([]string{"a", "b", "c"})) {
+		if i == 2 {
+			break
+		}
+		indices = append(indices, i)
+		values = append(values, v)
+	}
+	if !reflect.DeepEqual(indices, []int{0, 1}) || !reflect.DeepEqual(values, []string{"a", "b"}) {
+		t.Errorf("Enumerate = %v, %v; want [0 1], [a b]", indices, values)
+	}
+}
+
+func TestSeqStopsEarly(t *testing.T) {
+	// Breaking out of a pipeline must stop every stage without yielding again
+	pipelines := map[string]iter.Seq[int]{
+		"filter":     FilterSeq(naturals(), isEven),
+		"map":        MapSeq(naturals(), func(n int) int { return n }),
+		"take while": TakeWhile(naturals(), func(int) bool { return true }),
+		"take":       Take(naturals(), 100),
+		"skip":       Skip(naturals(), 1),
+	}
+	for name, seq := range pipelines {
+		count := 0
+		for range seq {
+			count++
+			if count == 3 {
+				break
+			}
+		}
+		if count != 3 {
+			t.Errorf("%s: got %d elements before break; want 3", name, count)
+		}
+	}
+}
+
+func TestSeqAllocations(t *testing.T) {
+	sumOfSquares := func(n int) func() {
+		return func() {
+			total := 0
+			seq := MapSeq(FilterSeq(Take(naturals(), n), isEven), func(v int) int { return v * v })
+			for v := range seq {
+				total += v
+			}
+		}
+	}
+	small := testing.AllocsPerRun(10, sumOfSquares(10))
+	large := testing.AllocsPerRun(10, sumOfSquares(100000))
+	if large != small {
+		t.Errorf("pipeline allocates %v times for 100000 elements and %v for 10; want no per-element allocation", large, small)
+	}
+}